@@ -0,0 +1,408 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+// MaxRequests is the most requests the Graph API accepts in a single $batch call.
+const MaxRequests = 20
+
+// Request is a single request in a batch.
+type Request struct {
+	// ID identifies the request within the batch. One is assigned by Add when left empty.
+	ID string
+
+	// Method is the HTTP method of the request, such as "GET".
+	Method string
+
+	// URL is the url of the request relative to the version specifier, such as "/users/{id}".
+	URL string
+
+	// Header holds additional headers for the request. Content-Type defaults to application/json
+	// when there is a Body.
+	Header map[string]string
+
+	// Body is marshalled to JSON as the request body, if set.
+	Body interface{}
+
+	// DependsOn lists the IDs of requests which must succeed before this one is run. Requests
+	// which depend on each other always end up in the same $batch call.
+	DependsOn []string
+
+	// Result, if set, is where a successful response body is decoded into once the batch is sent.
+	Result interface{}
+}
+
+// Response is the response to a single request in a batch.
+type Response struct {
+	ID     string            `json:"id"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"headers"`
+	Body   json.RawMessage   `json:"body"`
+}
+
+// Err returns a *client.GraphError if the request failed, and nil otherwise.
+func (r *Response) Err() error {
+	if r.Status >= 200 && r.Status <= 299 {
+		return nil
+	}
+	header := http.Header{}
+	for k, v := range r.Header {
+		header.Set(k, v)
+	}
+	return client.NewGraphError(r.Status, header, r.Body)
+}
+
+// Decode unmarshals the response body into v, or returns the error the request failed with.
+func (r *Response) Decode(v interface{}) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if v == nil || len(r.Body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Body, v)
+}
+
+// Responses maps request IDs to their responses.
+type Responses map[string]*Response
+
+// Errors returns the error of every request in the batch which failed, by request ID.
+func (r Responses) Errors() map[string]error {
+	errs := map[string]error{}
+	for id, resp := range r {
+		if err := resp.Err(); err != nil {
+			errs[id] = err
+		}
+	}
+	return errs
+}
+
+// Batch collects requests to send through the $batch endpoint. Any number of requests can be
+// added; they are split into as many $batch calls as needed when sent. Sub-requests which are
+// throttled are retried according to the client's RetryPolicy.
+//
+// https://docs.microsoft.com/en-us/graph/json-batching
+type Batch struct {
+	client   client.Client
+	version  string
+	requests []*Request
+	ids      map[string]bool
+	err      error
+}
+
+// New creates an empty batch against the v1.0 Graph API.
+func New(c client.Client) *Batch {
+	return NewWithVersion(c, "v1.0")
+}
+
+// NewWithVersion creates an empty batch against the given Graph API version, such as "beta".
+func NewWithVersion(c client.Client, version string) *Batch {
+	return &Batch{
+		client:  c,
+		version: version,
+		ids:     map[string]bool{},
+	}
+}
+
+// methods are the HTTP methods a batched request may use.
+var methods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// Add queues a request and returns its ID. An invalid request, such as one reusing an ID or using a
+// method other than GET, POST, PUT, PATCH or DELETE, makes Send fail.
+func (b *Batch) Add(r Request) string {
+	if r.ID == "" {
+		for n := len(b.requests) + 1; r.ID == "" || b.ids[r.ID]; n++ {
+			r.ID = strconv.Itoa(n)
+		}
+	}
+	if b.ids[r.ID] && b.err == nil {
+		b.err = fmt.Errorf("batch: duplicate request id %v", r.ID)
+	}
+	r.Method = strings.ToUpper(r.Method)
+	if !methods[r.Method] && b.err == nil {
+		b.err = fmt.Errorf("batch: request %v has unsupported method %q", r.ID, r.Method)
+	}
+	if !strings.HasPrefix(r.URL, "/") {
+		r.URL = "/" + r.URL
+	}
+	b.ids[r.ID] = true
+	b.requests = append(b.requests, &r)
+	return r.ID
+}
+
+// Len returns the number of requests queued.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Send sends every queued request and returns their responses.
+func (b *Batch) Send() (Responses, error) {
+	return b.SendWithContext(context.Background())
+}
+
+// SendWithContext is the same as Send, with the requests bound to ctx. The error is only set when
+// a $batch call itself fails; the errors of individual requests are in their Response.
+func (b *Batch) SendWithContext(ctx context.Context) (Responses, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	chunks, err := b.plan()
+	if err != nil {
+		return nil, err
+	}
+	responses := Responses{}
+	for _, chunk := range chunks {
+		if err := b.sendChunk(ctx, chunk, responses); err != nil {
+			return responses, err
+		}
+	}
+	for _, r := range b.requests {
+		if r.Result == nil {
+			continue
+		}
+		if resp := responses[r.ID]; resp != nil && resp.Err() == nil {
+			if err := resp.Decode(r.Result); err != nil {
+				return responses, err
+			}
+		}
+	}
+	return responses, nil
+}
+
+// plan splits the queued requests into chunks of at most MaxRequests, keeping requests which
+// depend on each other in the same chunk.
+func (b *Batch) plan() ([][]*Request, error) {
+	index := map[string]int{}
+	for i, r := range b.requests {
+		index[r.ID] = i
+	}
+	parent := make([]int, len(b.requests))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i, r := range b.requests {
+		for _, dep := range r.DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("batch: request %v depends on unknown request %v", r.ID, dep)
+			}
+			parent[find(i)] = find(j)
+		}
+	}
+	var roots []int
+	groups := map[int][]*Request{}
+	for i, r := range b.requests {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], r)
+	}
+	var chunks [][]*Request
+	var current []*Request
+	for _, root := range roots {
+		group := groups[root]
+		if len(group) > MaxRequests {
+			return nil, fmt.Errorf("batch: %v requests depend on each other, more than the %v allowed in one batch", len(group), MaxRequests)
+		}
+		if len(current)+len(group) > MaxRequests {
+			chunks = append(chunks, current)
+			current = nil
+		}
+		current = append(current, group...)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
+
+// sendChunk sends a single chunk of requests, retrying throttled requests along with the requests
+// which failed because they depended on them.
+func (b *Batch) sendChunk(ctx context.Context, chunk []*Request, responses Responses) error {
+	policy := client.OptionsFor(b.client).RetryPolicy()
+	retrier := internal.NewRetrier(policy)
+	pending := chunk
+	for len(pending) > 0 {
+		got, err := b.post(ctx, pending)
+		if err != nil {
+			return err
+		}
+		retry := map[string]bool{}
+		var throttled *Response
+		for _, r := range pending {
+			resp, ok := got[r.ID]
+			if !ok {
+				return fmt.Errorf("batch: no response for request %v", r.ID)
+			}
+			responses[r.ID] = resp
+			if retryable(resp) && internal.CanReplay(policy, r.Method) {
+				retry[r.ID] = true
+				throttled = slowest(throttled, resp)
+			}
+		}
+		if len(retry) == 0 {
+			return nil
+		}
+		for added := true; added; {
+			added = false
+			for _, r := range pending {
+				if retry[r.ID] || responses[r.ID].Status != http.StatusFailedDependency {
+					continue
+				}
+				for _, dep := range r.DependsOn {
+					if retry[dep] {
+						retry[r.ID] = true
+						added = true
+						break
+					}
+				}
+			}
+		}
+		wait, ok := retrier.Next(throttled.Err())
+		if !ok {
+			return nil
+		}
+		if err := internal.Sleep(ctx, wait); err != nil {
+			return err
+		}
+		var next []*Request
+		for _, r := range pending {
+			if retry[r.ID] {
+				next = append(next, r)
+			}
+		}
+		pending = next
+	}
+	return nil
+}
+
+// replayable reports whether every request may be retried under the client's RetryPolicy.
+func (b *Batch) replayable(requests []*Request) bool {
+	policy := client.OptionsFor(b.client).RetryPolicy()
+	for _, r := range requests {
+		if !internal.CanReplay(policy, r.Method) {
+			return false
+		}
+	}
+	return true
+}
+
+type batchRequest struct {
+	ID        string            `json:"id"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Header    map[string]string `json:"headers,omitempty"`
+	Body      interface{}       `json:"body,omitempty"`
+	DependsOn []string          `json:"dependsOn,omitempty"`
+}
+
+// post makes a single $batch call. Dependencies on requests outside of requests, which already
+// succeeded in an earlier call, are dropped.
+func (b *Batch) post(ctx context.Context, requests []*Request) (map[string]*Response, error) {
+	included := map[string]bool{}
+	for _, r := range requests {
+		included[r.ID] = true
+	}
+	var payload struct {
+		Requests []batchRequest `json:"requests"`
+	}
+	for _, r := range requests {
+		br := batchRequest{
+			ID:     r.ID,
+			Method: r.Method,
+			URL:    r.URL,
+			Header: r.Header,
+			Body:   r.Body,
+		}
+		if r.Body != nil {
+			if _, ok := r.Header["Content-Type"]; !ok {
+				br.Header = map[string]string{"Content-Type": "application/json"}
+				for k, v := range r.Header {
+					br.Header[k] = v
+				}
+			}
+		}
+		for _, dep := range r.DependsOn {
+			if included[dep] {
+				br.DependsOn = append(br.DependsOn, dep)
+			}
+		}
+		payload.Requests = append(payload.Requests, br)
+	}
+	// The $batch call itself is a POST, which the RetryPolicy won't replay by default. Sending it
+	// again is safe when every request in it is, so throttling of the whole batch is retried then.
+	var body []byte
+	var err error
+	if b.replayable(requests) {
+		body, err = internal.GraphRequestReplayable(ctx, b.client, "POST", b.version+"/$batch", payload)
+	} else {
+		body, err = internal.GraphRequestWithContext(ctx, b.client, "POST", b.version+"/$batch", nil, payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Responses []*Response `json:"responses"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	got := map[string]*Response{}
+	for _, resp := range data.Responses {
+		got[resp.ID] = resp
+	}
+	return got, nil
+}
+
+// retryable reports whether a sub-request was throttled or failed transiently.
+func retryable(resp *Response) bool {
+	switch resp.Status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// slowest returns whichever of the responses asks to wait the longest before retrying.
+func slowest(a, b *Response) *Response {
+	if a == nil {
+		return b
+	}
+	if retryAfterSecs(b) > retryAfterSecs(a) {
+		return b
+	}
+	return a
+}
+
+func retryAfterSecs(r *Response) int {
+	for k, v := range r.Header {
+		if strings.EqualFold(k, "Retry-After") {
+			secs, _ := strconv.Atoi(v)
+			return secs
+		}
+	}
+	return -1
+}
@@ -0,0 +1,98 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+)
+
+type staticClient struct {
+	creds *client.RequestCredentials
+	opts  *client.Options
+}
+
+func (c *staticClient) Credentials() *client.RequestCredentials { return c.creds }
+func (c *staticClient) InitializeCredentials() error            { return nil }
+func (c *staticClient) RefreshCredentials() error               { return nil }
+func (c *staticClient) RequestOptions() *client.Options         { return c.opts }
+
+func TestBatchRetriesThrottledRequestsAndDependents(t *testing.T) {
+	var calls [][]batchRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		calls = append(calls, payload.Requests)
+		var responses []Response
+		for _, req := range payload.Requests {
+			resp := Response{ID: req.ID, Status: http.StatusOK, Body: json.RawMessage(fmt.Sprintf(`{"id":%q}`, req.ID))}
+			if len(calls) == 1 && req.ID == "a" {
+				resp = Response{ID: req.ID, Status: http.StatusTooManyRequests, Header: map[string]string{"Retry-After": "0"}}
+			}
+			if len(calls) == 1 && req.ID == "b" {
+				resp = Response{ID: req.ID, Status: http.StatusFailedDependency}
+			}
+			responses = append(responses, resp)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer srv.Close()
+	c := &staticClient{
+		creds: &client.RequestCredentials{AccessToken: "token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+		opts:  &client.Options{GraphURL: srv.URL, Retry: &client.RetryPolicy{MaxAttempts: 2}},
+	}
+	b := New(c)
+	var result struct {
+		ID string `json:"id"`
+	}
+	b.Add(Request{ID: "a", Method: "GET", URL: "/me"})
+	b.Add(Request{ID: "b", Method: "GET", URL: "/me/manager", DependsOn: []string{"a"}, Result: &result})
+	for i := 0; i < MaxRequests; i++ {
+		b.Add(Request{Method: "GET", URL: "/users"})
+	}
+	responses, err := b.Send()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses.Errors()) != 0 || result.ID != "b" {
+		t.Fatalf("expected every request to succeed, got %v", responses.Errors())
+	}
+	if len(calls) != 3 || len(calls[0]) != MaxRequests || len(calls[1]) != 2 || len(calls[2]) != 2 {
+		t.Fatalf("unexpected batch calls %v", calls)
+	}
+}
+
+func TestBatchRetriesThrottledEnvelope(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"responses": []Response{{ID: "1", Status: http.StatusOK}}})
+	}))
+	defer srv.Close()
+	c := &staticClient{
+		creds: &client.RequestCredentials{AccessToken: "token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+		opts:  &client.Options{GraphURL: srv.URL, Retry: &client.RetryPolicy{MaxAttempts: 2}},
+	}
+	b := New(c)
+	b.Add(Request{Method: "get", URL: "/me"})
+	if _, err := b.Send(); err != nil || calls != 2 {
+		t.Fatalf("expected the throttled batch to be sent again, got %v after %v calls", err, calls)
+	}
+
+	b = New(c)
+	b.Add(Request{URL: "/me"})
+	if _, err := b.Send(); err == nil {
+		t.Fatal("expected a request without a method to be rejected")
+	}
+}
@@ -0,0 +1,3 @@
+// Package batch implements JSON batching, which combines many Graph API requests into a few round
+// trips to the $batch endpoint.
+package batch
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// ClientAssertionType is the client_assertion_type sent along with a client assertion.
+const ClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// assertionLifetime is how long the client assertions signed with a Certificate are valid for.
+const assertionLifetime = 10 * time.Minute
+
+// AssertionFunc returns a signed JWT to authenticate a client with, in place of an application
+// secret. It is called for every token request, so it can hand out short-lived tokens, such as
+// the ones issued to workloads federated with Azure AD.
+type AssertionFunc func(ctx context.Context) (string, error)
+
+// AssertionFromFile returns an AssertionFunc reading the assertion from the file at path on every
+// call, since such files are rotated by whatever issues them. Kubernetes workload identity
+// mounts its token at the path in the AZURE_FEDERATED_TOKEN_FILE environment variable.
+func AssertionFromFile(path string) AssertionFunc {
+	return func(ctx context.Context) (string, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+}
+
+// Certificate is an X.509 certificate along with its private key, which an application can
+// authenticate with in place of an application secret once the certificate is uploaded to its
+// registration. The key signs a client assertion for every token request.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+type Certificate struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+}
+
+// NewCertificate pairs a certificate with its private key. Azure AD only accepts assertions signed
+// with RSA keys.
+func NewCertificate(cert *x509.Certificate, key crypto.Signer) (*Certificate, error) {
+	if cert == nil || key == nil {
+		return nil, fmt.Errorf("a certificate and a private key are required")
+	}
+	if _, ok := key.Public().(*rsa.PublicKey); !ok {
+		return nil, fmt.Errorf("unsupported private key type %T, an RSA key is required", key.Public())
+	}
+	if !reflect.DeepEqual(cert.PublicKey, key.Public()) {
+		return nil, fmt.Errorf("the private key doesn't match the certificate")
+	}
+	return &Certificate{Certificate: cert, PrivateKey: key}, nil
+}
+
+// ParseCertificatePEM reads a certificate and its unencrypted private key from PEM blocks, in
+// PKCS#8 or PKCS#1 form. Both can be in the same data, as long as the certificate matching the
+// key is there.
+func ParseCertificatePEM(data []byte) (*Certificate, error) {
+	var certs []*x509.Certificate
+	var key crypto.Signer
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY":
+			k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := k.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unsupported private key type %T", k)
+			}
+			key = signer
+		case "RSA PRIVATE KEY":
+			if _, ok := block.Headers["DEK-Info"]; ok {
+				return nil, fmt.Errorf("encrypted PEM private keys are not supported, use PKCS#12 instead")
+			}
+			k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			key = k
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, fmt.Errorf("encrypted PEM private keys are not supported, use PKCS#12 instead")
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no private key found in PEM data")
+	}
+	for _, cert := range certs {
+		if reflect.DeepEqual(cert.PublicKey, key.Public()) {
+			return NewCertificate(cert, key)
+		}
+	}
+	return nil, fmt.Errorf("no certificate matching the private key found in PEM data")
+}
+
+// ParseCertificatePKCS12 reads a certificate and its private key from PKCS#12 (.pfx or .p12)
+// data, such as a certificate exported from Windows or Azure Key Vault.
+func ParseCertificatePKCS12(data []byte, password string) (*Certificate, error) {
+	key, cert, _, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return NewCertificate(cert, signer)
+}
+
+// Thumbprint returns the SHA-1 thumbprint of the certificate, as shown on the app registration.
+func (c *Certificate) Thumbprint() string {
+	sum := sha1.Sum(c.Certificate.Raw)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Assertion signs a client assertion for applicationID, to be sent to the token endpoint at
+// audience. It is an RS256 JWT carrying the certificate thumbprint in its x5t header, valid for
+// ten minutes.
+func (c *Certificate) Assertion(applicationID, audience string) (string, error) {
+	sum := sha1.Sum(c.Certificate.Raw)
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		return "", err
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": audience,
+		"iss": applicationID,
+		"sub": applicationID,
+		"jti": hex.EncodeToString(jti),
+		"nbf": now.Unix(),
+		"iat": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := c.PrivateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
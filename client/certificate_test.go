@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T) *Certificate {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "msgoraph"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCertificate(cert, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestHeadlessCertificateAssertion(t *testing.T) {
+	cert := testCertificate(t)
+	var tokenURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("client_secret") != "" || r.Form.Get("client_assertion_type") != ClientAssertionType {
+			t.Errorf("unexpected credentials %v", r.Form)
+		}
+		parts := strings.Split(r.Form.Get("client_assertion"), ".")
+		if len(parts) != 3 {
+			t.Errorf("malformed assertion %q", r.Form.Get("client_assertion"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var header, claims map[string]interface{}
+		for i, v := range []*map[string]interface{}{&header, &claims} {
+			b, _ := base64.RawURLEncoding.DecodeString(parts[i])
+			json.Unmarshal(b, v)
+		}
+		sum := sha1.Sum(cert.Certificate.Raw)
+		if header["alg"] != "RS256" || header["x5t"] != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			t.Errorf("unexpected header %v", header)
+		}
+		if claims["aud"] != tokenURL || claims["iss"] != "app" || claims["sub"] != "app" || claims["jti"] == "" {
+			t.Errorf("unexpected claims %v", claims)
+		}
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(cert.Certificate.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], sig); err != nil {
+			t.Errorf("bad signature: %v", err)
+		}
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	tokenURL = srv.URL + "/tenant/oauth2/v2.0/token"
+	c := NewHeadlessWithCertificate("app", cert, nil)
+	c.TenantID = "tenant"
+	c.Options = &Options{AuthorityHost: srv.URL}
+	if err := c.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if c.RequestCredentials.AccessToken != "token" {
+		t.Fatalf("unexpected access token %q", c.RequestCredentials.AccessToken)
+	}
+}
+
+func TestHeadlessAssertionFunc(t *testing.T) {
+	var assertion string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		assertion = r.Form.Get("client_assertion")
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	c := NewHeadlessWithAssertion("app", func(ctx context.Context) (string, error) {
+		return "federated", nil
+	}, nil)
+	c.TenantID = "tenant"
+	c.Options = &Options{AuthorityHost: srv.URL}
+	if err := c.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if assertion != "federated" {
+		t.Fatalf("unexpected assertion %q", assertion)
+	}
+}
+
+// The files in testdata were exported by OpenSSL with the password "secret": aes.p12 with its
+// defaults, 3des.p12 with -keypbe PBE-SHA1-3DES -certpbe PBE-SHA1-3DES -macalg sha1, and rc2.p12
+// with -legacy.
+func TestParseCertificatePKCS12(t *testing.T) {
+	for _, name := range []string{"aes.p12", "3des.p12", "rc2.p12"} {
+		data, err := ioutil.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := ParseCertificatePKCS12(data, "secret")
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if _, ok := cert.PrivateKey.(*rsa.PrivateKey); !ok {
+			t.Fatalf("%v: unexpected key type %T", name, cert.PrivateKey)
+		}
+		if got := strings.ToLower(cert.Thumbprint()); got != "6ed090ec762f00ed37dd9a4f3153048845f73fe8" {
+			t.Fatalf("%v: unexpected certificate thumbprint %v", name, got)
+		}
+		if _, err := ParseCertificatePKCS12(data, "wrong"); err == nil {
+			t.Fatalf("%v: expected a wrong password to be rejected", name)
+		}
+	}
+}
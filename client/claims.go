@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+// Claims are the claims of an access token which tell what it grants, and to whom. They are read
+// without verifying the token's signature; only the Graph API can tell whether a token is valid,
+// so they are meant for checking a token up front, not for making security decisions.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/access-tokens
+type Claims struct {
+	// Scopes are the delegated permissions granted to the application, from the scp claim.
+	Scopes []string
+	// Roles are the application permissions granted to the application, from the roles claim.
+	Roles []string
+	// TenantID is the tenant the token was issued by, from the tid claim.
+	TenantID string
+	// ObjectID is the object id of the user or service principal the token was issued for, from
+	// the oid claim.
+	ObjectID string
+	// ApplicationID is the id of the application the token was issued to, from the appid claim,
+	// or the azp claim of v2.0 tokens.
+	ApplicationID string
+	ExpiresAt     time.Time
+}
+
+type tokenClaims struct {
+	Scp   string   `json:"scp"`
+	Roles []string `json:"roles"`
+	Tid   string   `json:"tid"`
+	Oid   string   `json:"oid"`
+	AppID string   `json:"appid"`
+	Azp   string   `json:"azp"`
+	Exp   int64    `json:"exp"`
+}
+
+// ParseClaims reads the claims of a JWT access token, without verifying it.
+func ParseClaims(accessToken string) (*Claims, error) {
+	var tc tokenClaims
+	if err := decodeJWTPayload(accessToken, &tc); err != nil {
+		return nil, err
+	}
+	claims := &Claims{
+		Scopes:        strings.Fields(tc.Scp),
+		Roles:         tc.Roles,
+		TenantID:      tc.Tid,
+		ObjectID:      tc.Oid,
+		ApplicationID: tc.AppID,
+	}
+	if claims.ApplicationID == "" {
+		claims.ApplicationID = tc.Azp
+	}
+	if tc.Exp > 0 {
+		claims.ExpiresAt = time.Unix(tc.Exp, 0)
+	}
+	return claims, nil
+}
+
+// Claims reads the claims of the current access token, without verifying it.
+func (rc *RequestCredentials) Claims() (*Claims, error) {
+	token, _ := rc.Token()
+	if token == "" {
+		return nil, fmt.Errorf("no access token")
+	}
+	return ParseClaims(token)
+}
+
+// Missing returns the scopes of required which the token doesn't grant. Delegated scopes are
+// looked up in Scopes and application scopes in Roles, ignoring case. A permission is only granted
+// by its exact name; one which implies it, such as User.ReadWrite.All for User.Read.All, doesn't
+// count.
+func (c *Claims) Missing(required scopes.Scopes) scopes.Scopes {
+	var missing scopes.Scopes
+	for _, scope := range required {
+		granted := c.Scopes
+		if scope.Type == scopes.PermissionTypeApplication {
+			granted = c.Roles
+		}
+		if !containsFold(granted, scope.Permission) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// MissingScopesError is returned by VerifyScopes when the access token lacks some of the required
+// scopes.
+type MissingScopesError struct {
+	Missing scopes.Scopes
+}
+
+func (e *MissingScopesError) Error() string {
+	var names []string
+	for _, scope := range e.Missing {
+		names = append(names, fmt.Sprintf("%v (%v)", scope.Permission, scope.Type))
+	}
+	return fmt.Sprintf("access token is missing scopes: %v", strings.Join(names, ", "))
+}
+
+// VerifyScopes checks that the access token of c grants every scope in required, so that a job
+// can fail before it starts rather than on a 403 halfway through. The credentials of c are
+// refreshed first if needed. It returns a *MissingScopesError listing the scopes which aren't
+// granted, if any.
+func VerifyScopes(ctx context.Context, c Client, required scopes.Scopes) error {
+	token, err := AccessToken(ctx, c)
+	if err != nil {
+		return err
+	}
+	claims, err := ParseClaims(token)
+	if err != nil {
+		return err
+	}
+	if missing := claims.Missing(required); len(missing) > 0 {
+		return &MissingScopesError{Missing: missing}
+	}
+	return nil
+}
+
+// decodeJWTPayload unmarshals the payload of a JWT into v, without verifying it.
+func decodeJWTPayload(token string, v interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("token is not a JWT")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return fmt.Errorf("could not decode token payload: %v", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not parse token payload: %v", err)
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+func TestVerifyScopes(t *testing.T) {
+	payload := `{"scp":"User.Read user.readbasic.all","tid":"tenant","oid":"user","azp":"app","exp":2000000000}`
+	token := "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"` + token + `","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	c := NewHeadless("app", "secret", nil)
+	c.TenantID = "tenant"
+	c.Options = &Options{AuthorityHost: srv.URL}
+
+	if err := VerifyScopes(context.Background(), c, scopes.Scopes{scopes.DelegatedUserRead, scopes.DelegatedUserReadBasicAll}); err != nil {
+		t.Fatalf("expected the scopes to be granted, got %v", err)
+	}
+	err := VerifyScopes(context.Background(), c, scopes.Scopes{scopes.DelegatedUserRead, scopes.DelegatedUserReadAll, scopes.ApplicationUserReadAll})
+	var missing *MissingScopesError
+	if !errors.As(err, &missing) || len(missing.Missing) != 2 || missing.Missing[0] != scopes.DelegatedUserReadAll || missing.Missing[1] != scopes.ApplicationUserReadAll {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	claims, err := c.Credentials().Claims()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.TenantID != "tenant" || claims.ObjectID != "user" || claims.ApplicationID != "app" || claims.ExpiresAt.Unix() != 2000000000 {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+	if _, err := ParseClaims("opaque"); err == nil {
+		t.Fatal("expected an opaque token to be rejected")
+	}
+}
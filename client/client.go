@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -21,10 +22,127 @@ type Client interface {
 	RefreshCredentials() error
 }
 
+// ContextClient is implemented by clients whose credential requests can be bound to a
+// context.Context, so they can be cancelled or given a deadline. Every client type in this package
+// implements it.
+type ContextClient interface {
+	Client
+
+	// InitializeCredentialsWithContext is InitializeCredentials bound to ctx.
+	InitializeCredentialsWithContext(ctx context.Context) error
+
+	// RefreshCredentialsWithContext is RefreshCredentials bound to ctx.
+	RefreshCredentialsWithContext(ctx context.Context) error
+}
+
+// RefreshWithContext makes sure c holds an access token which is valid for longer than the
+// renewal margin of its Options, refreshing its credentials with ctx if it doesn't. Concurrent
+// calls for the same credentials share a single refresh. Clients which aren't a ContextClient are
+// refreshed with c.RefreshCredentials, after checking that ctx is not already done.
+func RefreshWithContext(ctx context.Context, c Client) error {
+	creds := c.Credentials()
+	if creds.Valid(OptionsFor(c).RenewBefore()) {
+		return nil
+	}
+	return creds.refresh(ctx, func(ctx context.Context) error {
+		if cc, ok := c.(ContextClient); ok {
+			return cc.RefreshCredentialsWithContext(ctx)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return c.RefreshCredentials()
+	})
+}
+
+// AccessToken returns an access token of c to authenticate a request with, refreshing its
+// credentials first if needed, as RefreshWithContext does.
+func AccessToken(ctx context.Context, c Client) (string, error) {
+	if err := RefreshWithContext(ctx, c); err != nil {
+		return "", err
+	}
+	token, _ := c.Credentials().Token()
+	return token, nil
+}
+
 // RequestCredentials stores all the information necessary to authenticate a request with the
 // Microsoft GraphAPI
+//
+// Clients hold AccessTokenUpdating while they request a token, and go through SetToken and Token
+// rather than the fields, so that the credentials can be read while they are being refreshed.
 type RequestCredentials struct {
 	AccessToken          string
 	AccessTokenExpiresAt time.Time
 	AccessTokenUpdating  sync.Mutex
+
+	mu       sync.RWMutex
+	inflight *refreshCall
+}
+
+// refreshCall is a refresh in progress, which concurrent callers wait on.
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Token returns the access token and when it expires.
+func (rc *RequestCredentials) Token() (string, time.Time) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.AccessToken, rc.AccessTokenExpiresAt
+}
+
+// SetToken replaces the access token.
+func (rc *RequestCredentials) SetToken(accessToken string, expiresAt time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.AccessToken = accessToken
+	rc.AccessTokenExpiresAt = expiresAt
+}
+
+// Valid reports whether there is an access token which is valid for at least margin longer.
+func (rc *RequestCredentials) Valid(margin time.Duration) bool {
+	token, expiresAt := rc.Token()
+	return token != "" && time.Now().Add(margin).Before(expiresAt)
+}
+
+// Invalidate marks accessToken as expired if it's still the current access token, so that the
+// next request refreshes the credentials; for when the Graph API rejected it before its time.
+func (rc *RequestCredentials) Invalidate(accessToken string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.AccessToken == accessToken {
+		rc.AccessTokenExpiresAt = time.Time{}
+	}
+}
+
+// refresh runs fn unless a refresh is already in progress, in which case it waits for that one
+// and returns its result instead. If the refresh in progress was cancelled by the context of its
+// caller, while ctx is still live, fn is run again.
+func (rc *RequestCredentials) refresh(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		rc.mu.Lock()
+		call := rc.inflight
+		if call == nil {
+			call = &refreshCall{done: make(chan struct{})}
+			rc.inflight = call
+			rc.mu.Unlock()
+			call.err = fn(ctx)
+			rc.mu.Lock()
+			rc.inflight = nil
+			rc.mu.Unlock()
+			close(call.done)
+			return call.err
+		}
+		rc.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-call.done:
+		}
+		if (call.err == context.Canceled || call.err == context.DeadlineExceeded) && ctx.Err() == nil {
+			continue
+		}
+		return call.err
+	}
 }
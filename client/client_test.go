@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshWithContextSharesRefresh(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	c := NewHeadless("app", "secret", nil)
+	c.TenantID = "tenant"
+	c.Options = &Options{AuthorityHost: srv.URL}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if token, err := AccessToken(context.Background(), c); err != nil || token != "token" {
+				t.Errorf("unexpected token %q, %v", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if requests != 1 {
+		t.Fatalf("expected a single token request, got %v", requests)
+	}
+}
+
+func TestRefreshWithContextRenewsEarly(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"access_token":"renewed","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	c := NewHeadless("app", "secret", nil)
+	c.TenantID = "tenant"
+	c.Options = &Options{AuthorityHost: srv.URL, RenewalMargin: 10 * time.Minute}
+	c.RequestCredentials.SetToken("old", time.Now().Add(5*time.Minute))
+
+	if err := RefreshWithContext(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := c.Credentials().Token(); token != "renewed" || requests != 1 {
+		t.Fatalf("expected the token to be renewed within the margin, got %q after %v requests", token, requests)
+	}
+	if err := RefreshWithContext(context.Background(), c); err != nil || requests != 1 {
+		t.Fatalf("expected the renewed token to be reused, got %v after %v requests", err, requests)
+	}
+
+	c.Credentials().Invalidate("stale")
+	if !c.Credentials().Valid(0) {
+		t.Fatal("expected invalidating another token to leave the credentials alone")
+	}
+	c.Credentials().Invalidate("renewed")
+	if c.Credentials().Valid(0) {
+		t.Fatal("expected the invalidated token to need a refresh")
+	}
+}
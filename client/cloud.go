@@ -0,0 +1,98 @@
+package client
+
+import (
+	"strings"
+)
+
+// Cloud describes a deployment of Microsoft Graph: the global service, or one of the national
+// clouds which run separately from it. Each has its own Graph API endpoint and login authority,
+// and tokens issued in one are not accepted by any other.
+//
+// https://docs.microsoft.com/en-us/graph/deployments
+type Cloud struct {
+	Name          string
+	GraphURL      string
+	AuthorityHost string
+}
+
+var (
+	// CloudGlobal is the global Microsoft Graph service.
+	CloudGlobal = Cloud{
+		Name:          "global",
+		GraphURL:      DefaultGraphURL,
+		AuthorityHost: DefaultAuthorityHost,
+	}
+	// CloudUSGov is Microsoft Graph for US Government L4.
+	CloudUSGov = Cloud{
+		Name:          "usgov",
+		GraphURL:      "https://graph.microsoft.us/",
+		AuthorityHost: "https://login.microsoftonline.us/",
+	}
+	// CloudUSGovDoD is Microsoft Graph for US Government L5 (DOD).
+	CloudUSGovDoD = Cloud{
+		Name:          "usgov-dod",
+		GraphURL:      "https://dod-graph.microsoft.us/",
+		AuthorityHost: "https://login.microsoftonline.us/",
+	}
+	// CloudChina is Microsoft Graph China operated by 21Vianet.
+	CloudChina = Cloud{
+		Name:          "china",
+		GraphURL:      "https://microsoftgraph.chinacloudapi.cn/",
+		AuthorityHost: "https://login.chinacloudapi.cn/",
+	}
+	// CloudGermany is Microsoft Graph for Germany.
+	CloudGermany = Cloud{
+		Name:          "germany",
+		GraphURL:      "https://graph.microsoft.de/",
+		AuthorityHost: "https://login.microsoftonline.de/",
+	}
+)
+
+// Clouds lists every known Cloud.
+var Clouds = []Cloud{
+	CloudGlobal,
+	CloudUSGov,
+	CloudUSGovDoD,
+	CloudChina,
+	CloudGermany,
+}
+
+// LookupCloud finds a known Cloud by name, case insensitively.
+func LookupCloud(name string) (Cloud, bool) {
+	for _, c := range Clouds {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return Cloud{}, false
+}
+
+// CloudOptions returns Options which send every request of a client to the given cloud.
+func CloudOptions(cloud Cloud) *Options {
+	return &Options{Cloud: &cloud}
+}
+
+// Resource returns the Graph resource identifier tokens for this cloud are issued for.
+func (c Cloud) Resource() string {
+	return withTrailingSlash(c.GraphURL)
+}
+
+// DefaultScope returns the "/.default" scope of the Graph resource in this cloud, which is what
+// app-only token requests ask for.
+func (c Cloud) DefaultScope() string {
+	return c.Resource() + ".default"
+}
+
+// QualifyScope prefixes a bare Graph permission such as "User.Read" with the Graph resource of
+// this cloud. Outside the global cloud, bare permission names are not resolved to the right
+// resource. OpenID Connect scopes and scopes which already name a resource are left untouched.
+func (c Cloud) QualifyScope(scope string) string {
+	switch strings.ToLower(scope) {
+	case "", "openid", "profile", "email", "offline_access":
+		return scope
+	}
+	if strings.Contains(scope, "://") || strings.HasPrefix(scope, "api:") {
+		return scope
+	}
+	return c.Resource() + scope
+}
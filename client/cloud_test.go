@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHeadlessRequestsCloudScope(t *testing.T) {
+	var scope string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		scope = r.Form.Get("scope")
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	c := NewHeadless("app", "secret", nil)
+	c.TenantID = "tenant"
+	c.Options = CloudOptions(CloudChina)
+	c.Options.AuthorityHost = srv.URL
+	if err := c.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if scope != "https://microsoftgraph.chinacloudapi.cn/.default" {
+		t.Fatalf("unexpected scope %q", scope)
+	}
+	if got := c.Options.GraphRoot(); got != CloudChina.GraphURL {
+		t.Fatalf("unexpected graph root %q", got)
+	}
+}
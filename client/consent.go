@@ -0,0 +1,154 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+// Values of ConsentRequest.Prompt.
+const (
+	PromptConsent       = "consent"
+	PromptLogin         = "login"
+	PromptNone          = "none"
+	PromptSelectAccount = "select_account"
+)
+
+// ConsentRequest builds the links which send administrators or users to the Microsoft identity
+// platform to grant an application permissions: when onboarding a customer tenant, or when a new
+// feature needs more permissions than were granted so far.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-permissions-and-consent
+type ConsentRequest struct {
+	// TenantID is the tenant to ask for consent in. It defaults to "organizations", which lets the
+	// administrator of any tenant sign in.
+	TenantID      string
+	ApplicationID string
+	RedirectURI   string
+	Scopes        scopes.Scopes
+	// Prompt is one of the Prompt constants, or empty to let the identity platform decide. Admin
+	// consent always prompts.
+	Prompt string
+	// LoginHint pre-fills the username of the user signing in.
+	LoginHint string
+	// DomainHint skips home realm discovery, such as "contoso.com" for a federated tenant.
+	DomainHint string
+	// State is echoed back in the callback, to be checked against the one sent.
+	State   string
+	Options *Options
+}
+
+// AdminConsentURL returns the link asking an administrator to grant Scopes to the application for
+// their whole tenant. Delegated and application permissions can be requested alike; when Scopes is
+// empty, every permission configured on the application registration is requested.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-admin-consent
+func (r *ConsentRequest) AdminConsentURL() string {
+	cloud := r.Options.TargetCloud()
+	var scp []string
+	for _, s := range r.Scopes {
+		scp = append(scp, cloud.QualifyScope(s.Permission))
+	}
+	if len(scp) == 0 {
+		scp = append(scp, cloud.DefaultScope())
+	}
+	q := r.hints()
+	q.Set("client_id", r.ApplicationID)
+	q.Set("scope", strings.Join(scp, " "))
+	q.Set("redirect_uri", r.RedirectURI)
+	return fmt.Sprintf("%v%v/v2.0/adminconsent?%v", r.Options.Authority(), r.tenant(), encodeQuery(q))
+}
+
+// AuthorizeURL returns the link asking the user signing in to grant Scopes to the application, with
+// an authorization code sent back to RedirectURI. Scopes only needs to list the permissions a new
+// feature adds; the ones granted before are kept.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow
+func (r *ConsentRequest) AuthorizeURL() string {
+	var permissions []string
+	for _, s := range r.Scopes {
+		permissions = append(permissions, s.Permission)
+	}
+	return r.authorizeURL(permissions, nil)
+}
+
+// authorizeURL returns the /authorize link for permissions, with extra parameters added.
+func (r *ConsentRequest) authorizeURL(permissions []string, extra url.Values) string {
+	q := r.hints()
+	if r.Prompt != "" {
+		q.Set("prompt", r.Prompt)
+	}
+	q.Set("client_id", r.ApplicationID)
+	q.Set("response_type", "code")
+	q.Set("response_mode", "query")
+	q.Set("scope", r.Options.ScopeString(permissions))
+	q.Set("redirect_uri", r.RedirectURI)
+	for k, v := range extra {
+		q[k] = v
+	}
+	return fmt.Sprintf("%v%v/oauth2/v2.0/authorize?%v", r.Options.Authority(), r.tenant(), encodeQuery(q))
+}
+
+// hints returns the optional parameters the consent endpoints share.
+func (r *ConsentRequest) hints() url.Values {
+	q := url.Values{}
+	if r.State != "" {
+		q.Set("state", r.State)
+	}
+	if r.LoginHint != "" {
+		q.Set("login_hint", r.LoginHint)
+	}
+	if r.DomainHint != "" {
+		q.Set("domain_hint", r.DomainHint)
+	}
+	return q
+}
+
+func (r *ConsentRequest) tenant() string {
+	if r.TenantID == "" {
+		return "organizations"
+	}
+	return r.TenantID
+}
+
+// encodeQuery encodes q with spaces as "%20", since not every identity platform endpoint reads
+// "+" back as a space inside of the scope parameter.
+func encodeQuery(q url.Values) string {
+	return strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+// ConsentResult is what the identity platform redirected back with after a consent request.
+type ConsentResult struct {
+	// AdminConsent is set when an administrator granted the permissions for their tenant.
+	AdminConsent bool
+	// TenantID is the tenant the administrator granted the permissions in.
+	TenantID string
+	// Scopes are the permissions granted through admin consent.
+	Scopes []string
+	// Code is the authorization code of an AuthorizeURL callback.
+	Code  string
+	State string
+}
+
+// ParseConsentCallback reads the query parameters the identity platform redirected back to
+// RedirectURI with. A declined or failed consent is returned as a *TokenError, such as one with
+// the code "access_denied". The state is returned as is; checking it against the one sent is up to
+// the caller.
+func ParseConsentCallback(params url.Values) (*ConsentResult, error) {
+	if code := params.Get("error"); code != "" {
+		return nil, &TokenError{Code: code, Description: params.Get("error_description")}
+	}
+	result := &ConsentResult{
+		AdminConsent: strings.EqualFold(params.Get("admin_consent"), "true"),
+		TenantID:     params.Get("tenant"),
+		Scopes:       strings.Fields(params.Get("scope")),
+		Code:         params.Get("code"),
+		State:        params.Get("state"),
+	}
+	if !result.AdminConsent && result.Code == "" {
+		return nil, fmt.Errorf("consent callback carries neither admin_consent nor a code")
+	}
+	return result, nil
+}
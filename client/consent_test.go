@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+func TestConsentURLs(t *testing.T) {
+	r := &ConsentRequest{
+		ApplicationID: "app",
+		RedirectURI:   "https://example.com/consent?x=1",
+		Scopes:        scopes.Scopes{scopes.ApplicationUserReadAll, scopes.ApplicationGroupReadAll},
+		Prompt:        PromptConsent,
+		LoginHint:     "admin@contoso.com",
+		DomainHint:    "contoso.com",
+		State:         "a b&c",
+	}
+	admin, err := url.Parse(r.AdminConsentURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := admin.Query()
+	if admin.Path != "/organizations/v2.0/adminconsent" || q.Get("redirect_uri") != r.RedirectURI || q.Get("state") != "a b&c" || q.Get("login_hint") != "admin@contoso.com" {
+		t.Fatalf("unexpected admin consent url %v", admin)
+	}
+	if q.Get("scope") != "https://graph.microsoft.com/User.Read.All https://graph.microsoft.com/Group.Read.All" || q.Get("prompt") != "" {
+		t.Fatalf("unexpected admin consent url %v", admin)
+	}
+	if strings.Contains(admin.RawQuery, "+") {
+		t.Fatalf("expected spaces to be encoded as %%20 in %v", admin.RawQuery)
+	}
+
+	r.TenantID = "contoso.com"
+	r.Scopes = scopes.Scopes{scopes.DelegatedMailRead}
+	authorize, _ := url.Parse(r.AuthorizeURL())
+	q = authorize.Query()
+	if authorize.Path != "/contoso.com/oauth2/v2.0/authorize" || q.Get("scope") != "Mail.Read" || q.Get("prompt") != "consent" || q.Get("domain_hint") != "contoso.com" || q.Get("response_type") != "code" {
+		t.Fatalf("unexpected authorize url %v", authorize)
+	}
+
+	w := NewWeb("tenant", "app", "", "http://localhost/login", []string{"User.Read"})
+	incremental, _ := url.Parse(w.IncrementalAuthorization(scopes.Scopes{scopes.DelegatedUserRead, scopes.DelegatedMailSend}))
+	q = incremental.Query()
+	if q.Get("scope") != "User.Read Mail.Send" || q.Get("prompt") != "consent" || q.Get("state") != w.State || q.Get("code_challenge") == "" {
+		t.Fatalf("unexpected incremental authorize url %v", incremental)
+	}
+}
+
+func TestParseConsentCallback(t *testing.T) {
+	result, err := ParseConsentCallback(url.Values{"admin_consent": {"True"}, "tenant": {"tenant-id"}, "state": {"s"}, "scope": {"https://graph.microsoft.com/User.Read.All"}})
+	if err != nil || !result.AdminConsent || result.TenantID != "tenant-id" || result.State != "s" || len(result.Scopes) != 1 {
+		t.Fatalf("unexpected result %+v, %v", result, err)
+	}
+	_, err = ParseConsentCallback(url.Values{"error": {"access_denied"}, "error_description": {"AADSTS65004: User declined to consent"}})
+	if terr, ok := err.(*TokenError); !ok || terr.Code != "access_denied" {
+		t.Fatalf("expected the consent error, got %v", err)
+	}
+	if _, err := ParseConsentCallback(url.Values{"state": {"s"}}); err == nil {
+		t.Fatal("expected a callback without a result to be rejected")
+	}
+}
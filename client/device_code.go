@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+// DeviceAuthorization is what a user needs to sign a DeviceCode client in from another device:
+// the code to enter, and the page to enter it at.
+type DeviceAuthorization struct {
+	UserCode        string
+	VerificationURI string
+	// Message holds instructions for the user, localized by Azure AD, mentioning both the code and
+	// the page.
+	Message   string
+	ExpiresAt time.Time
+}
+
+// DeviceCode is used to authenticate requests on behalf of a user signing in from another device,
+// such as in command line tools run over SSH, where there is no browser to redirect to. The user
+// is handed a code through Prompt, which they enter on a Microsoft page while the client polls
+// for the token. DelegatedOfflineAccess is always requested, so that the credentials can be
+// refreshed without signing in again. With Options.TokenCache set, they are saved under Account,
+// so that the user doesn't have to sign in again on the next run either.
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-device-code
+type DeviceCode struct {
+	Account            string
+	ApplicationID      string
+	Error              error
+	Options            *Options
+	Prompt             func(DeviceAuthorization)
+	RefreshToken       string
+	RequestCredentials *RequestCredentials
+	Scopes             scopes.Scopes
+	TenantID           string
+
+	signIn sync.Mutex
+}
+
+// minPollInterval is the least time waited between polls for the token, whatever interval Azure AD
+// asks for.
+var minPollInterval = time.Second
+
+// NewDeviceCode creates a new client.DeviceCode connection. prompt is called with the code the
+// user has to enter once InitializeCredentials is called; when nil, the instructions are printed
+// to stderr.
+func NewDeviceCode(tenantID, applicationID string, scopes scopes.Scopes, prompt func(DeviceAuthorization)) *DeviceCode {
+	return &DeviceCode{
+		ApplicationID:      applicationID,
+		Prompt:             prompt,
+		RequestCredentials: &RequestCredentials{},
+		Scopes:             scopes,
+		TenantID:           tenantID,
+	}
+}
+
+// Credentials returns back the set of request credentials in this client. Conforms to the
+// client.Client interface.
+func (d *DeviceCode) Credentials() *RequestCredentials {
+	return d.RequestCredentials
+}
+
+// RequestOptions returns the Options used for requests made with this client. Conforms to the
+// client.Configured interface.
+func (d *DeviceCode) RequestOptions() *Options {
+	return d.Options
+}
+
+// InitializeCredentials requests a device code, hands it to the user through Prompt, and waits
+// for them to sign in. It blocks until they do, decline, or the code expires.
+func (d *DeviceCode) InitializeCredentials() error {
+	return d.InitializeCredentialsWithContext(context.Background())
+}
+
+// InitializeCredentialsWithContext is the same as InitializeCredentials, with the wait for the
+// user bound to ctx. The credentials aren't locked while waiting, so concurrent requests keep
+// using the current access token until the user signs in.
+func (d *DeviceCode) InitializeCredentialsWithContext(ctx context.Context) error {
+	// Only one sign in runs at a time; concurrent callers wait for it, then find the credentials
+	// valid.
+	d.signIn.Lock()
+	defer d.signIn.Unlock()
+	if ok, err := d.restore(ctx); ok || err != nil {
+		return err
+	}
+	data, err := postForm(ctx, d.Options, d.endpoint("devicecode"), url.Values{
+		"client_id": {d.ApplicationID},
+		"scope":     {d.scope()},
+	})
+	if err != nil {
+		return err
+	}
+	deviceCode, _ := data["device_code"].(string)
+	if deviceCode == "" {
+		return fmt.Errorf("no device code found in response")
+	}
+	auth := DeviceAuthorization{}
+	auth.UserCode, _ = data["user_code"].(string)
+	auth.VerificationURI, _ = data["verification_uri"].(string)
+	auth.Message, _ = data["message"].(string)
+	expiresIn, _ := data["expires_in"].(float64)
+	auth.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	interval := 5 * time.Second
+	if secs, ok := data["interval"].(float64); ok {
+		interval = time.Duration(secs) * time.Second
+	}
+	if interval < minPollInterval {
+		interval = minPollInterval
+	}
+	if d.Prompt != nil {
+		d.Prompt(auth)
+	} else {
+		fmt.Fprintln(os.Stderr, auth.Message)
+	}
+
+	for {
+		if time.Now().After(auth.ExpiresAt) {
+			return fmt.Errorf("client.DeviceCode: the device code expired before the user signed in")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		token, err := requestToken(ctx, d.Options, d.endpoint("token"), url.Values{
+			"client_id":   {d.ApplicationID},
+			"device_code": {deviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		})
+		var tokenErr *TokenError
+		if errors.As(err, &tokenErr) {
+			switch tokenErr.Code {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += 5 * time.Second
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+		d.RequestCredentials.AccessTokenUpdating.Lock()
+		defer d.RequestCredentials.AccessTokenUpdating.Unlock()
+		d.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+		d.RefreshToken = token.RefreshToken
+		return saveToken(ctx, d.Options, d.cacheKey(), d.RequestCredentials, d.RefreshToken, "")
+	}
+}
+
+// restore makes the credentials valid without the user signing in, from the token cache or a saved
+// refresh token, and reports whether it did.
+func (d *DeviceCode) restore(ctx context.Context) (bool, error) {
+	d.RequestCredentials.AccessTokenUpdating.Lock()
+	defer d.RequestCredentials.AccessTokenUpdating.Unlock()
+	if d.RequestCredentials.Valid(d.Options.RenewBefore()) {
+		return true, nil
+	}
+	refreshToken, err := loadToken(ctx, d.Options, d.cacheKey(), d.RequestCredentials, "")
+	if err != nil {
+		return false, err
+	}
+	if d.RequestCredentials.Valid(d.Options.RenewBefore()) {
+		d.RefreshToken = refreshToken
+		return true, nil
+	}
+	if refreshToken != "" {
+		// The user only has to sign in again if the saved refresh token was revoked or expired.
+		d.RefreshToken = refreshToken
+		if d.refresh(ctx) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RefreshCredentials refreshes the access token with the refresh token obtained when the user
+// signed in, if it has expired.
+func (d *DeviceCode) RefreshCredentials() error {
+	return d.RefreshCredentialsWithContext(context.Background())
+}
+
+// RefreshCredentialsWithContext is the same as RefreshCredentials, with the token request bound
+// to ctx.
+func (d *DeviceCode) RefreshCredentialsWithContext(ctx context.Context) error {
+	d.RequestCredentials.AccessTokenUpdating.Lock()
+	defer d.RequestCredentials.AccessTokenUpdating.Unlock()
+	if d.RequestCredentials.Valid(d.Options.RenewBefore()) {
+		return nil
+	}
+	if d.RefreshToken == "" {
+		refreshToken, err := loadToken(ctx, d.Options, d.cacheKey(), d.RequestCredentials, "")
+		if err != nil {
+			return err
+		}
+		d.RefreshToken = refreshToken
+		if d.RequestCredentials.Valid(d.Options.RenewBefore()) {
+			return nil
+		}
+	}
+	if d.RefreshToken == "" {
+		return fmt.Errorf("client.DeviceCode: no refresh token found in device code client. call client.InitializeCredentials to fill this")
+	}
+	return d.refresh(ctx)
+}
+
+// refresh redeems the refresh token. One the token endpoint rejects, because it was revoked or
+// expired, is forgotten and removed from Options.TokenCache. The caller holds AccessTokenUpdating.
+func (d *DeviceCode) refresh(ctx context.Context) error {
+	token, err := requestToken(ctx, d.Options, d.endpoint("token"), url.Values{
+		"client_id":     {d.ApplicationID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {d.RefreshToken},
+		"scope":         {d.scope()},
+	})
+	if isInvalidGrant(err) {
+		d.RefreshToken = ""
+		return deleteToken(ctx, d.Options, d.cacheKey(), err)
+	}
+	if err != nil {
+		return err
+	}
+	d.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	if token.RefreshToken != "" {
+		d.RefreshToken = token.RefreshToken
+	}
+	return saveToken(ctx, d.Options, d.cacheKey(), d.RequestCredentials, d.RefreshToken, "")
+}
+
+// cacheKey returns the key of the client's tokens in a TokenCache.
+func (d *DeviceCode) cacheKey() CacheKey {
+	return NewCacheKey(d.endpointTenant(), d.ApplicationID, d.Account, permissionNames(d.Scopes))
+}
+
+// endpoint returns the url of the given oauth2 endpoint of the client's tenant.
+func (d *DeviceCode) endpoint(name string) string {
+	return d.Options.Authority() + d.endpointTenant() + "/oauth2/v2.0/" + name
+}
+
+// endpointTenant returns the tenant segment of the oauth2 endpoints.
+func (d *DeviceCode) endpointTenant() string {
+	if d.TenantID == "" {
+		return "common"
+	}
+	return d.TenantID
+}
+
+// scope returns the scopes of this client as a token request scope parameter, qualified for the
+// configured cloud, along with offline_access.
+func (d *DeviceCode) scope() string {
+	permissions := permissionNames(d.Scopes)
+	if !d.Scopes.HasScope(scopes.DelegatedOfflineAccess) {
+		permissions = append(permissions, scopes.DelegatedOfflineAccess.Permission)
+	}
+	return d.Options.ScopeString(permissions)
+}
+
+// permissionNames returns the permission names of s.
+func permissionNames(s scopes.Scopes) []string {
+	var names []string
+	for _, scope := range s {
+		names = append(names, scope.Permission)
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+func TestDeviceCodeFlow(t *testing.T) {
+	defer func(interval time.Duration) { minPollInterval = interval }(minPollInterval)
+	minPollInterval = 10 * time.Millisecond
+	var last time.Time
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.URL.Path {
+		case "/tenant/oauth2/v2.0/devicecode":
+			if r.Form.Get("scope") != "User.Read offline_access" {
+				t.Errorf("unexpected scope %q", r.Form.Get("scope"))
+			}
+			w.Write([]byte(`{"device_code":"device","user_code":"ABCD","verification_uri":"https://microsoft.com/devicelogin","expires_in":900,"interval":0}`))
+		case "/tenant/oauth2/v2.0/token":
+			switch r.Form.Get("grant_type") {
+			case "urn:ietf:params:oauth:grant-type:device_code":
+				if !last.IsZero() && time.Since(last) < minPollInterval {
+					t.Errorf("polled again after %v", time.Since(last))
+				}
+				last = time.Now()
+				if polls++; polls < 3 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"authorization_pending"}`))
+					return
+				}
+				w.Write([]byte(`{"access_token":"token","refresh_token":"refresh","expires_in":3600}`))
+			case "refresh_token":
+				if r.Form.Get("refresh_token") != "refresh" {
+					t.Errorf("unexpected refresh token %q", r.Form.Get("refresh_token"))
+				}
+				w.Write([]byte(`{"access_token":"refreshed","expires_in":3600}`))
+			}
+		default:
+			t.Errorf("unexpected request to %v", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	var auth DeviceAuthorization
+	var d *DeviceCode
+	d = NewDeviceCode("tenant", "app", scopes.Scopes{scopes.DelegatedUserRead}, func(a DeviceAuthorization) {
+		auth = a
+		if !d.RequestCredentials.AccessTokenUpdating.TryLock() {
+			t.Error("expected the credentials not to be locked while waiting for the user")
+			return
+		}
+		d.RequestCredentials.AccessTokenUpdating.Unlock()
+	})
+	d.Options = &Options{AuthorityHost: srv.URL}
+	if err := d.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if auth.UserCode != "ABCD" || polls != 3 || d.RequestCredentials.AccessToken != "token" || d.RefreshToken != "refresh" {
+		t.Fatalf("unexpected state %+v, %v polls, %+v", auth, polls, d)
+	}
+	d.RequestCredentials.AccessTokenExpiresAt = time.Now().Add(-time.Minute)
+	if err := d.RefreshCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if d.RequestCredentials.AccessToken != "refreshed" || d.RefreshToken != "refresh" {
+		t.Fatalf("unexpected credentials after refresh %+v", d.RequestCredentials)
+	}
+}
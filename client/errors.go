@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GraphError is returned whenever the Graph API answers a request with a non-2xx status code. It
+// carries the HTTP status along with the error object Microsoft puts in the response body, so
+// callers can inspect it with errors.As or use one of the Is* helpers in this package.
+//
+// https://docs.microsoft.com/en-us/graph/errors
+type GraphError struct {
+	StatusCode      int
+	Code            string
+	Message         string
+	RequestID       string
+	ClientRequestID string
+	Date            time.Time
+	Header          http.Header
+	Body            []byte
+}
+
+type graphErrorResponse struct {
+	Error struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		InnerError struct {
+			Date            string `json:"date"`
+			RequestID       string `json:"request-id"`
+			ClientRequestID string `json:"client-request-id"`
+		} `json:"innerError"`
+	} `json:"error"`
+}
+
+// NewGraphError builds a GraphError out of a failed response. The body is parsed as a Graph error
+// object when possible; if it isn't one, the error code falls back to the HTTP status text.
+func NewGraphError(statusCode int, header http.Header, body []byte) *GraphError {
+	gErr := &GraphError{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       body,
+	}
+	var data graphErrorResponse
+	if err := json.Unmarshal(body, &data); err == nil {
+		gErr.Code = data.Error.Code
+		gErr.Message = data.Error.Message
+		gErr.RequestID = data.Error.InnerError.RequestID
+		gErr.ClientRequestID = data.Error.InnerError.ClientRequestID
+		gErr.Date = parseErrorDate(data.Error.InnerError.Date)
+	}
+	if gErr.Code == "" {
+		gErr.Code = http.StatusText(statusCode)
+	}
+	if gErr.RequestID == "" && header != nil {
+		gErr.RequestID = header.Get("request-id")
+	}
+	if gErr.ClientRequestID == "" && header != nil {
+		gErr.ClientRequestID = header.Get("client-request-id")
+	}
+	return gErr
+}
+
+// parseErrorDate parses innerError.date, which Graph usually sends without a zone offset even
+// though it is UTC.
+func parseErrorDate(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Error conforms to the error interface.
+func (e *GraphError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "msgraph: %v %v", e.StatusCode, e.Code)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %v", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request-id: %v)", e.RequestID)
+	}
+	return b.String()
+}
+
+// AsGraphError returns the GraphError wrapped somewhere in err, if there is one.
+func AsGraphError(err error) (*GraphError, bool) {
+	var gErr *GraphError
+	if errors.As(err, &gErr) {
+		return gErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, statusCode int) bool {
+	gErr, ok := AsGraphError(err)
+	return ok && gErr.StatusCode == statusCode
+}
+
+// IsBadRequest returns true if err is a GraphError with a 400 status.
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized returns true if err is a GraphError with a 401 status.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden returns true if err is a GraphError with a 403 status.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound returns true if err is a GraphError with a 404 status.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict returns true if err is a GraphError with a 409 status.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsThrottled returns true if err is a GraphError telling the caller to slow down; either a 429,
+// or a 503 which came with a Retry-After header.
+func IsThrottled(err error) bool {
+	gErr, ok := AsGraphError(err)
+	if !ok {
+		return false
+	}
+	if gErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return gErr.StatusCode == http.StatusServiceUnavailable && gErr.Header.Get("Retry-After") != ""
+}
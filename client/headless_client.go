@@ -1,27 +1,37 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/cention-mujibur-rahman/msgoraph/scopes"
 )
 
 // Headless is used to authenticate requests in the context of a backend app. This is the most
 // common way for applications to authenticate with the api.
+//
+// The application authenticates with the first of Assertion, Certificate or ApplicationSecret
+// which is set. App-only tokens are issued by a specific tenant, so TenantID must be set to the ID
+// or a verified domain of the tenant to access; Azure AD rejects them on the "common" authority.
+// See TenantManager for accessing several tenants.
 type Headless struct {
 	ApplicationID      string
 	ApplicationSecret  string
+	Assertion          AssertionFunc
+	Certificate        *Certificate
 	Error              error
+	Options            *Options
 	RefreshToken       string
 	RequestCredentials *RequestCredentials
 	Scopes             scopes.Scopes
+	TenantID           string
 }
 
+// ErrNoTenant is returned when requesting tokens with a Headless client which has no TenantID.
+var ErrNoTenant = errors.New("client: TenantID must be set to request app-only tokens")
+
 // NewHeadless creates a new headless connection.
 func NewHeadless(applicationID string, applicationSecret string, scopes scopes.Scopes) *Headless {
 	return &Headless{
@@ -32,72 +42,133 @@ func NewHeadless(applicationID string, applicationSecret string, scopes scopes.S
 	}
 }
 
+// NewHeadlessWithCertificate creates a new headless connection authenticating with a certificate
+// rather than an application secret.
+func NewHeadlessWithCertificate(applicationID string, certificate *Certificate, scopes scopes.Scopes) *Headless {
+	return &Headless{
+		ApplicationID:      applicationID,
+		Certificate:        certificate,
+		RequestCredentials: &RequestCredentials{},
+		Scopes:             scopes,
+	}
+}
+
+// NewHeadlessWithAssertion creates a new headless connection authenticating with client
+// assertions obtained from assertion, such as the tokens of a federated workload identity.
+func NewHeadlessWithAssertion(applicationID string, assertion AssertionFunc, scopes scopes.Scopes) *Headless {
+	return &Headless{
+		ApplicationID:      applicationID,
+		Assertion:          assertion,
+		RequestCredentials: &RequestCredentials{},
+		Scopes:             scopes,
+	}
+}
+
 // Credentials returns back the set of credentials used for every request.
-func (h Headless) Credentials() *RequestCredentials {
+func (h *Headless) Credentials() *RequestCredentials {
 	return h.RequestCredentials
 }
 
-// InitializeCredentials will make an initial oauth2 token request for a new token.
-func (h Headless) InitializeCredentials() error {
+// RequestOptions returns the Options used for requests made with this client. Conforms to the
+// client.Configured interface.
+func (h *Headless) RequestOptions() *Options {
+	return h.Options
+}
+
+// InitializeCredentials will make an initial oauth2 token request for a new token. When the token
+// endpoint answers with invalid_grant, the client's token is removed from Options.TokenCache.
+func (h *Headless) InitializeCredentials() error {
+	return h.InitializeCredentialsWithContext(context.Background())
+}
+
+// InitializeCredentialsWithContext is the same as InitializeCredentials, with the token request
+// bound to ctx.
+func (h *Headless) InitializeCredentialsWithContext(ctx context.Context) error {
 	h.RequestCredentials.AccessTokenUpdating.Lock()
 	defer h.RequestCredentials.AccessTokenUpdating.Unlock()
-	if h.RequestCredentials.AccessToken != "" && h.RequestCredentials.AccessTokenExpiresAt.After(time.Now()) {
+	if h.RequestCredentials.Valid(h.Options.RenewBefore()) {
 		return nil
 	}
-	tokenURI, err := url.Parse("https://login.microsoftonline.com/common/oauth2/v2.0/token")
+	tenant, err := h.tenant()
 	if err != nil {
 		return err
 	}
-	resp, err := http.PostForm(tokenURI.String(), url.Values{
-		"client_id":     {h.ApplicationID},
-		"client_secret": {h.ApplicationSecret},
-		"grant_type":    {"client_credentials"},
-		"scope":         {"https://graph.microsoft.com/.default"},
-	})
-	if err != nil {
+	key := h.cacheKey(tenant)
+	if _, err := loadToken(ctx, h.Options, key, h.RequestCredentials, ""); err != nil {
 		return err
 	}
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	if h.RequestCredentials.Valid(h.Options.RenewBefore()) {
+		return nil
 	}
-	var data map[string]interface{}
-	err = json.Unmarshal(b, &data)
+	tokenURL := h.Options.Authority() + tenant + "/oauth2/v2.0/token"
+	form, err := h.clientCredentials(ctx, tokenURL)
 	if err != nil {
 		return err
 	}
-	serverErrCode, ok := data["error"].(string)
-	if ok {
-		serverErr, ok := data["error_description"].(string)
-		if ok {
-			return fmt.Errorf("%v: %v", serverErrCode, serverErr)
-		}
-		return fmt.Errorf(serverErrCode)
-	}
-	accessToken, ok := data["access_token"].(string)
-	if !ok || accessToken == "" {
-		return fmt.Errorf("no access token found in response")
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", h.Options.TargetCloud().DefaultScope())
+	token, err := requestToken(ctx, h.Options, tokenURL, form)
+	if isInvalidGrant(err) {
+		h.RefreshToken = ""
+		return deleteToken(ctx, h.Options, key, err)
 	}
-	durationSecs, ok := data["expires_in"].(float64)
-	if !ok || durationSecs == 0 {
-		return fmt.Errorf("no token duration found in response")
+	if err != nil {
+		return err
 	}
 	if h.Scopes.HasScope(scopes.DelegatedOfflineAccess) {
-		refreshToken, ok := data["refresh_token"].(string)
-		if !ok || refreshToken == "" {
+		if token.RefreshToken == "" {
 			return fmt.Errorf("no refresh token found in response")
 		}
-		h.RefreshToken = refreshToken
+		h.RefreshToken = token.RefreshToken
 	}
-	expiresAt := time.Now().Add(time.Duration(durationSecs) * time.Second)
-	h.RequestCredentials.AccessToken = accessToken
-	h.RequestCredentials.AccessTokenExpiresAt = expiresAt
-	return nil
+	h.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	return saveToken(ctx, h.Options, key, h.RequestCredentials, h.RefreshToken, "")
 }
 
 // RefreshCredentials will refresh the connection credentials. This just proxies through to
 // InitializeCredentials, because in the context of a headless appliction we should probably already
 // have the application secret key.
-func (h Headless) RefreshCredentials() error {
+func (h *Headless) RefreshCredentials() error {
 	return h.InitializeCredentials()
 }
+
+// RefreshCredentialsWithContext is the same as RefreshCredentials, with the token request bound
+// to ctx.
+func (h *Headless) RefreshCredentialsWithContext(ctx context.Context) error {
+	return h.InitializeCredentialsWithContext(ctx)
+}
+
+// tenant returns the tenant segment of the token endpoint, or ErrNoTenant if there is none.
+func (h *Headless) tenant() (string, error) {
+	if h.TenantID == "" {
+		return "", ErrNoTenant
+	}
+	return h.TenantID, nil
+}
+
+// cacheKey returns the key of the client's tokens from tenant in a TokenCache.
+func (h *Headless) cacheKey(tenant string) CacheKey {
+	return NewCacheKey(tenant, h.ApplicationID, "", []string{h.Options.TargetCloud().DefaultScope()})
+}
+
+// clientCredentials returns the form values the application authenticates with at tokenURL.
+func (h *Headless) clientCredentials(ctx context.Context, tokenURL string) (url.Values, error) {
+	form := url.Values{"client_id": {h.ApplicationID}}
+	var assertion string
+	var err error
+	switch {
+	case h.Assertion != nil:
+		assertion, err = h.Assertion(ctx)
+	case h.Certificate != nil:
+		assertion, err = h.Certificate.Assertion(h.ApplicationID, tokenURL)
+	default:
+		form.Set("client_secret", h.ApplicationSecret)
+		return form, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get client assertion: %v", err)
+	}
+	form.Set("client_assertion_type", ClientAssertionType)
+	form.Set("client_assertion", assertion)
+	return form, nil
+}
@@ -1,17 +1,39 @@
 package client
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/cention-mujibur-rahman/msgoraph/scopes"
 )
 
 func TestHeadlessClientInitialization(t *testing.T) {
-	applicationID := ""
-	applicationSecret := ""
+	var form url.Values
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form, path = r.PostForm, r.URL.Path
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	applicationID := "app"
+	applicationSecret := "secret"
 	c := NewHeadless(applicationID, applicationSecret, scopes.All(scopes.PermissionTypeApplication))
+	c.Options = &Options{AuthorityHost: srv.URL}
+	if err := c.InitializeCredentials(); err != ErrNoTenant {
+		t.Fatalf("expected ErrNoTenant without a TenantID, got %v", err)
+	}
+	c.TenantID = "tenant"
 	err := c.InitializeCredentials()
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
+	if path != "/tenant/oauth2/v2.0/token" || form.Get("grant_type") != "client_credentials" || form.Get("client_secret") != applicationSecret {
+		t.Fatalf("unexpected token request to %v: %v", path, form)
+	}
+	if c.Credentials().AccessToken != "token" {
+		t.Fatalf("unexpected credentials %+v", c.Credentials())
+	}
 }
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// callback is a login response received by the login server, handed over to InteractiveLogin.
+type callback struct {
+	params url.Values
+	result chan error
+}
+
+// InteractiveLogin signs a user in through their browser, for command line and desktop apps. It
+// serves the login callback on a loopback port of its own, LocalhostPort or a random free one when
+// unset, with RedirectURI set to http://127.0.0.1:<port>/login. Register http://127.0.0.1/login as
+// a redirect uri of the application; the port of a loopback redirect uri isn't matched, but the
+// path is. The authorize url is passed to open, such as OpenBrowser, or printed to stderr when open
+// is nil or fails. It blocks until the user is redirected back or ctx is done, then redeems the
+// authorization code and shuts the server down. Nothing is done if the client already has valid
+// credentials, or can refresh them.
+func (w *Web) InteractiveLogin(ctx context.Context, open func(authURL string) error) error {
+	// Credentials from an earlier login, such as ones saved in Options.TokenCache, spare the user
+	// from signing in again.
+	if err := w.RefreshCredentialsWithContext(ctx); err == nil {
+		return nil
+	}
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%v", w.LocalhostPort))
+	if err != nil {
+		return fmt.Errorf("client.Web: could not start login server: %v", err)
+	}
+	callbacks := make(chan callback)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(wr http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(wr, fmt.Sprintf("Error while parsing form from response %s", err), http.StatusBadRequest)
+			return
+		}
+		cb := callback{params: r.Form, result: make(chan error, 1)}
+		select {
+		case callbacks <- cb:
+		case <-r.Context().Done():
+			return
+		}
+		if err := <-cb.result; err != nil {
+			http.Error(wr, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(wr, "authorization done. you may close this window now")
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	w.RedirectURI = w.redirectURI(ln.Addr())
+	authURL := w.Authorization()
+	if authURL == "" {
+		return w.Error
+	}
+	if open == nil || open(authURL) != nil {
+		fmt.Fprintf(os.Stderr, "Open the following url in your browser to sign in:\n\n%v\n\n", authURL)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case cb := <-callbacks:
+			err := w.HandleCallback(cb.params)
+			cb.result <- err
+			if err == nil {
+				return w.setAccessToken(ctx)
+			}
+			if _, ok := err.(*TokenError); ok {
+				return err
+			}
+			// Anything else, such as a stale tab redirecting with the state of an earlier attempt,
+			// leaves the current attempt open.
+		}
+	}
+}
+
+// OpenBrowser opens u in the default browser of the user.
+func OpenBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
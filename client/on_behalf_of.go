@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+// OnBehalfOf lets a middle-tier API call the Graph API as the users calling it. It exchanges the
+// access tokens users send to the API, their assertions, for Graph tokens, with the credentials of
+// the API's own application, and caches a client per assertion.
+//
+//	obo := client.NewOnBehalfOf(client.NewHeadless(appID, secret, nil), scopes.Scopes{scopes.DelegatedUserRead})
+//	obo.App.TenantID = tenantID
+//	me, err := users.Service(obo.Client(bearerToken)).GetLoggedUser()
+//
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-on-behalf-of-flow
+type OnBehalfOf struct {
+	// App holds the credentials, tenant and Options of the API's application. Its Scopes are
+	// ignored.
+	App *Headless
+
+	// Scopes are the delegated permissions requested for the users.
+	Scopes scopes.Scopes
+
+	mu      sync.Mutex
+	clients map[string]*OnBehalfOfClient
+}
+
+// NewOnBehalfOf creates an OnBehalfOf requesting tokens with the given delegated permissions for
+// the application authenticated by app.
+func NewOnBehalfOf(app *Headless, scopes scopes.Scopes) *OnBehalfOf {
+	return &OnBehalfOf{
+		App:     app,
+		Scopes:  scopes,
+		clients: map[string]*OnBehalfOfClient{},
+	}
+}
+
+// Client returns the client acting as the user assertion was issued to. The same client is
+// returned for every call with the same assertion, so that its Graph token is only requested
+// once and then reused until it expires. Clients are dropped from the cache once their assertion
+// expires.
+func (o *OnBehalfOf) Client(assertion string) *OnBehalfOfClient {
+	sum := sha256.Sum256([]byte(assertion))
+	key := hex.EncodeToString(sum[:])
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if c, ok := o.clients[key]; ok {
+		return c
+	}
+	now := time.Now()
+	for k, c := range o.clients {
+		if c.assertionExpiresAt.Before(now) {
+			delete(o.clients, k)
+		}
+	}
+	c := &OnBehalfOfClient{
+		Assertion:          assertion,
+		RequestCredentials: &RequestCredentials{},
+		flow:               o,
+		assertionExpiresAt: assertionExpiry(assertion, now),
+	}
+	o.clients[key] = c
+	return c
+}
+
+// OnBehalfOfClient is a client acting as the user a single assertion was issued to. Get one from
+// OnBehalfOf.Client.
+type OnBehalfOfClient struct {
+	Assertion          string
+	RequestCredentials *RequestCredentials
+	// RefreshToken is issued along with the Graph token when DelegatedOfflineAccess is among the
+	// Scopes, and keeps the client working once the assertion has expired.
+	RefreshToken string
+
+	flow               *OnBehalfOf
+	assertionExpiresAt time.Time
+}
+
+// Credentials returns back the set of request credentials in this client. Conforms to the
+// client.Client interface.
+func (c *OnBehalfOfClient) Credentials() *RequestCredentials {
+	return c.RequestCredentials
+}
+
+// RequestOptions returns the Options used for requests made with this client. Conforms to the
+// client.Configured interface.
+func (c *OnBehalfOfClient) RequestOptions() *Options {
+	return c.flow.App.Options
+}
+
+// InitializeCredentials exchanges the assertion for a Graph token, or redeems the refresh token
+// of an earlier exchange, from the client or Options.TokenCache, when there is one.
+func (c *OnBehalfOfClient) InitializeCredentials() error {
+	return c.InitializeCredentialsWithContext(context.Background())
+}
+
+// InitializeCredentialsWithContext is the same as InitializeCredentials, with the token request
+// bound to ctx.
+func (c *OnBehalfOfClient) InitializeCredentialsWithContext(ctx context.Context) error {
+	c.RequestCredentials.AccessTokenUpdating.Lock()
+	defer c.RequestCredentials.AccessTokenUpdating.Unlock()
+	app := c.flow.App
+	if c.RequestCredentials.Valid(app.Options.RenewBefore()) {
+		return nil
+	}
+	tenant, err := app.tenant()
+	if err != nil {
+		return err
+	}
+	key := NewCacheKey(tenant, app.ApplicationID, c.account(), permissionNames(c.flow.Scopes))
+	refreshToken, err := loadToken(ctx, app.Options, key, c.RequestCredentials, "")
+	if err != nil {
+		return err
+	}
+	if c.RefreshToken == "" {
+		c.RefreshToken = refreshToken
+	}
+	if c.RequestCredentials.Valid(app.Options.RenewBefore()) {
+		return nil
+	}
+	tokenURL := app.Options.Authority() + tenant + "/oauth2/v2.0/token"
+	if c.RefreshToken != "" {
+		err := c.refresh(ctx, tokenURL, key)
+		if !isInvalidGrant(err) {
+			return err
+		}
+		// The refresh token was revoked or expired, but the assertion may still be good.
+	}
+	form, err := app.clientCredentials(ctx, tokenURL)
+	if err != nil {
+		return err
+	}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", c.Assertion)
+	form.Set("requested_token_use", "on_behalf_of")
+	form.Set("scope", app.Options.ScopeString(permissionNames(c.flow.Scopes)))
+	token, err := requestToken(ctx, app.Options, tokenURL, form)
+	if err != nil {
+		return err
+	}
+	c.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	c.RefreshToken = token.RefreshToken
+	return saveToken(ctx, app.Options, key, c.RequestCredentials, c.RefreshToken, "")
+}
+
+// refresh redeems the refresh token at tokenURL. One the token endpoint rejects is forgotten and
+// removed from Options.TokenCache. The caller holds AccessTokenUpdating.
+func (c *OnBehalfOfClient) refresh(ctx context.Context, tokenURL string, key CacheKey) error {
+	app := c.flow.App
+	form, err := app.clientCredentials(ctx, tokenURL)
+	if err != nil {
+		return err
+	}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", c.RefreshToken)
+	form.Set("scope", app.Options.ScopeString(permissionNames(c.flow.Scopes)))
+	token, err := requestToken(ctx, app.Options, tokenURL, form)
+	if isInvalidGrant(err) {
+		c.RefreshToken = ""
+		return deleteToken(ctx, app.Options, key, err)
+	}
+	if err != nil {
+		return err
+	}
+	c.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	if token.RefreshToken != "" {
+		c.RefreshToken = token.RefreshToken
+	}
+	return saveToken(ctx, app.Options, key, c.RequestCredentials, c.RefreshToken, "")
+}
+
+// account identifies the assertion in a TokenCache, without saving the assertion itself.
+func (c *OnBehalfOfClient) account() string {
+	sum := sha256.Sum256([]byte(c.Assertion))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshCredentials gets a new Graph token if the current one expired, with the refresh token
+// when there is one. Without one, the assertion is exchanged again, which fails once the assertion
+// itself has expired.
+func (c *OnBehalfOfClient) RefreshCredentials() error {
+	return c.InitializeCredentials()
+}
+
+// RefreshCredentialsWithContext is the same as RefreshCredentials, with the token request bound
+// to ctx.
+func (c *OnBehalfOfClient) RefreshCredentialsWithContext(ctx context.Context) error {
+	return c.InitializeCredentialsWithContext(ctx)
+}
+
+// assertionExpiry reads the expiry of a JWT assertion, without verifying it, since that is up to
+// the API receiving it. Assertions it can't be read from are assumed to be valid for an hour.
+func assertionExpiry(assertion string, now time.Time) time.Time {
+	var claims tokenClaims
+	if decodeJWTPayload(assertion, &claims) == nil && claims.Exp > 0 {
+		return time.Unix(claims.Exp, 0)
+	}
+	return now.Add(time.Hour)
+}
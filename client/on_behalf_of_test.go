@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+func TestOnBehalfOf(t *testing.T) {
+	exchanges := map[string]int{}
+	var refreshes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != "User.Read" {
+			t.Errorf("unexpected token request to %v: %v", r.URL.Path, r.Form)
+		}
+		if r.Form.Get("grant_type") == "refresh_token" {
+			refreshes = append(refreshes, r.Form.Get("refresh_token"))
+			if r.Form.Get("refresh_token") == "revoked" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"refreshed","refresh_token":"rotated","expires_in":3600}`))
+			return
+		}
+		if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || r.Form.Get("requested_token_use") != "on_behalf_of" {
+			t.Errorf("unexpected token request to %v: %v", r.URL.Path, r.Form)
+		}
+		assertion := r.Form.Get("assertion")
+		exchanges[assertion]++
+		w.Write([]byte(`{"access_token":"graph-` + assertion + `","refresh_token":"refresh-` + assertion + `","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	app := NewHeadless("app", "secret", nil)
+	app.TenantID = "tenant"
+	cache := NewMemoryTokenCache()
+	app.Options = &Options{AuthorityHost: srv.URL, TokenCache: cache}
+	obo := NewOnBehalfOf(app, scopes.Scopes{scopes.DelegatedUserRead})
+
+	alice, bob := obo.Client("alice"), obo.Client("bob")
+	for _, c := range []*OnBehalfOfClient{alice, bob, obo.Client("alice")} {
+		if err := c.RefreshCredentials(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if alice.Credentials().AccessToken != "graph-alice" || bob.Credentials().AccessToken != "graph-bob" {
+		t.Fatalf("unexpected tokens %q and %q", alice.Credentials().AccessToken, bob.Credentials().AccessToken)
+	}
+	if exchanges["alice"] != 1 || exchanges["bob"] != 1 {
+		t.Fatalf("expected a single exchange per assertion, got %v", exchanges)
+	}
+	alice.RequestCredentials.AccessTokenExpiresAt = time.Now().Add(-time.Minute)
+	if err := alice.RefreshCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if exchanges["alice"] != 1 || len(refreshes) != 1 || refreshes[0] != "refresh-alice" || alice.Credentials().AccessToken != "refreshed" {
+		t.Fatalf("expected an expired token to be refreshed, got %v exchanges and %v refreshes", exchanges, refreshes)
+	}
+	key := NewCacheKey("tenant", "app", alice.account(), []string{"User.Read"})
+	if token, _ := cache.LoadToken(context.Background(), key); token == nil || token.RefreshToken != "rotated" {
+		t.Fatalf("expected the rotated refresh token to be saved, got %+v", token)
+	}
+	alice.RequestCredentials.AccessTokenExpiresAt = time.Now().Add(-time.Minute)
+	alice.RefreshToken = "revoked"
+	if err := alice.RefreshCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if exchanges["alice"] != 2 || alice.RefreshToken != "refresh-alice" {
+		t.Fatalf("expected a revoked refresh token to fall back to the assertion, got %v exchanges", exchanges)
+	}
+
+	expired := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1}`)) + ".signature"
+	obo.Client(expired)
+	obo.Client("carol")
+	if len(obo.clients) != 3 {
+		t.Fatalf("expected the client of an expired assertion to be dropped, have %v", len(obo.clients))
+	}
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultGraphURL is the root url that the Graph API is hosted on in the global cloud.
+	DefaultGraphURL = "https://graph.microsoft.com/"
+
+	// DefaultAuthorityHost is the root url of the Azure AD login authority in the global cloud.
+	DefaultAuthorityHost = "https://login.microsoftonline.com/"
+
+	// DefaultRenewalMargin is how long before they expire access tokens are renewed by default.
+	DefaultRenewalMargin = 5 * time.Minute
+)
+
+// Middleware wraps the transport used to send requests, so requests and responses can be
+// inspected or modified; for logging, adding headers, collecting metrics and so on.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface, which is
+// mostly useful when writing a Middleware.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip conforms to the http.RoundTripper interface.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Options configures how requests made on behalf of a client are sent to the Graph API and to the
+// login authority. A nil or empty field means the package default is used.
+type Options struct {
+	// HTTPClient is the client every request is sent with. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Cloud is the Microsoft Graph deployment requests and tokens are made against. Nil means
+	// CloudGlobal.
+	Cloud *Cloud
+
+	// GraphURL overrides the root url of the Graph API, such as with a proxy or a local stub
+	// server in tests. Empty means the GraphURL of the Cloud. Tokens are still requested for the
+	// Graph resource of the Cloud.
+	GraphURL string
+
+	// AuthorityHost overrides the root url of the login authority tokens are requested from. Empty
+	// means the AuthorityHost of the Cloud.
+	AuthorityHost string
+
+	// Middleware wraps the transport of HTTPClient. The first Middleware is the outermost, so it
+	// sees each request first and each response last.
+	Middleware []Middleware
+
+	// Retry is the policy used to retry throttled and transiently failing requests. Nil means
+	// DefaultRetryPolicy.
+	Retry *RetryPolicy
+
+	// RenewalMargin is how long before they expire access tokens are renewed, so that a token is
+	// never sent when it's about to expire. Zero means DefaultRenewalMargin; a negative margin
+	// disables early renewal.
+	RenewalMargin time.Duration
+
+	// TokenCache is where clients look for tokens before requesting new ones, and save the tokens
+	// they get, including rotated refresh tokens. Nil means tokens are only kept in memory by each
+	// client.
+	TokenCache TokenCache
+}
+
+// Configured is implemented by clients which carry their own Options. Requests made through a
+// Client which doesn't implement it use the package defaults.
+type Configured interface {
+	RequestOptions() *Options
+}
+
+// OptionsFor returns the Options configured on c, or an empty set of Options if there are none.
+func OptionsFor(c Client) *Options {
+	if cc, ok := c.(Configured); ok {
+		if opts := cc.RequestOptions(); opts != nil {
+			return opts
+		}
+	}
+	return &Options{}
+}
+
+// RenewBefore returns the configured renewal margin, or DefaultRenewalMargin.
+func (o *Options) RenewBefore() time.Duration {
+	if o == nil || o.RenewalMargin == 0 {
+		return DefaultRenewalMargin
+	}
+	if o.RenewalMargin < 0 {
+		return 0
+	}
+	return o.RenewalMargin
+}
+
+// RetryPolicy returns the configured retry policy, or DefaultRetryPolicy.
+func (o *Options) RetryPolicy() RetryPolicy {
+	if o == nil || o.Retry == nil {
+		return DefaultRetryPolicy
+	}
+	return *o.Retry
+}
+
+// TargetCloud returns the configured Cloud, or CloudGlobal.
+func (o *Options) TargetCloud() Cloud {
+	if o == nil || o.Cloud == nil {
+		return CloudGlobal
+	}
+	return *o.Cloud
+}
+
+// GraphRoot returns the configured Graph API root url, always ending in a slash.
+func (o *Options) GraphRoot() string {
+	if o == nil || o.GraphURL == "" {
+		return withTrailingSlash(o.TargetCloud().GraphURL)
+	}
+	return withTrailingSlash(o.GraphURL)
+}
+
+// Authority returns the configured login authority root url, always ending in a slash.
+func (o *Options) Authority() string {
+	if o == nil || o.AuthorityHost == "" {
+		return withTrailingSlash(o.TargetCloud().AuthorityHost)
+	}
+	return withTrailingSlash(o.AuthorityHost)
+}
+
+// ScopeString joins permissions into the space separated scope parameter of a token request.
+// Outside the global cloud, every Graph permission is qualified with the Graph resource of the
+// configured Cloud.
+func (o *Options) ScopeString(permissions []string) string {
+	cloud := o.TargetCloud()
+	var scp []string
+	for _, p := range permissions {
+		if p == "" {
+			continue
+		}
+		if cloud.Name != CloudGlobal.Name {
+			p = cloud.QualifyScope(p)
+		}
+		scp = append(scp, p)
+	}
+	return strings.Join(scp, " ")
+}
+
+// HTTP returns the http.Client requests should be sent with, with the configured Middleware
+// wrapped around its transport.
+func (o *Options) HTTP() *http.Client {
+	base := http.DefaultClient
+	if o != nil && o.HTTPClient != nil {
+		base = o.HTTPClient
+	}
+	if o == nil || len(o.Middleware) == 0 {
+		return base
+	}
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(o.Middleware) - 1; i >= 0; i-- {
+		transport = o.Middleware[i](transport)
+	}
+	c := *base
+	c.Transport = transport
+	return &c
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
+	}
+	return s + "/"
+}
+
+// WithOptions attaches opts to a client which doesn't carry Options of its own, such as a custom
+// Client implementation. The returned Client proxies everything else through to c.
+func WithOptions(c Client, opts *Options) Client {
+	return &configuredClient{Client: c, opts: opts}
+}
+
+type configuredClient struct {
+	Client
+	opts *Options
+}
+
+func (c *configuredClient) RequestOptions() *Options {
+	return c.opts
+}
+
+func (c *configuredClient) InitializeCredentialsWithContext(ctx context.Context) error {
+	if cc, ok := c.Client.(ContextClient); ok {
+		return cc.InitializeCredentialsWithContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Client.InitializeCredentials()
+}
+
+func (c *configuredClient) RefreshCredentialsWithContext(ctx context.Context) error {
+	return RefreshWithContext(ctx, c.Client)
+}
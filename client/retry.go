@@ -0,0 +1,46 @@
+package client
+
+import (
+	"time"
+)
+
+// RetryPolicy controls how requests against the Graph API are retried when Microsoft throttles
+// them (429) or a gateway fails transiently (503, 504), along with network errors that happen
+// before a response arrives. A Retry-After header on the response is always honoured; without
+// one, the delay grows exponentially from BaseDelay with full jitter, up to MaxDelay.
+//
+// https://docs.microsoft.com/en-us/graph/throttling
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first one.
+	// A value of 1 disables retries.
+	MaxAttempts int
+
+	// MaxElapsed caps the total time spent on a request across all attempts. A retry whose delay
+	// would run past it is not made. Zero means no cap.
+	MaxElapsed time.Duration
+
+	// BaseDelay is the delay before the first retry when the response carries no Retry-After.
+	BaseDelay time.Duration
+
+	// MaxDelay caps every computed backoff delay. It does not cap a Retry-After sent by the server.
+	MaxDelay time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests to be replayed. These are not retried by
+	// default, since replaying them may apply the same change twice.
+	RetryNonIdempotent bool
+}
+
+var (
+	// DefaultRetryPolicy is used for every client which doesn't configure its own policy.
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts: 4,
+		MaxElapsed:  2 * time.Minute,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+
+	// NoRetryPolicy makes every request exactly once.
+	NoRetryPolicy = RetryPolicy{
+		MaxAttempts: 1,
+	}
+)
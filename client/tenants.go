@@ -0,0 +1,77 @@
+package client
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TenantManager hands out Headless clients for the customer tenants of a multi-tenant
+// application. Every client authenticates the same way as the template it is created with, but
+// is bound to its own tenant and caches its own RequestCredentials, so tokens for one tenant are
+// never sent to another.
+//
+// Services are bound to a single tenant by being created with its client:
+//
+//	m := client.NewTenantManager(client.NewHeadless(appID, secret, nil))
+//	c, err := m.Tenant("contoso.onmicrosoft.com")
+//	if err != nil {
+//		return err
+//	}
+//	contoso := users.Service(c)
+type TenantManager struct {
+	template Headless
+	mu       sync.Mutex
+	clients  map[string]*Headless
+}
+
+// NewTenantManager creates a TenantManager whose clients are copies of template, which holds the
+// application's credentials, Options and Scopes. Its TenantID and credentials are ignored.
+func NewTenantManager(template *Headless) *TenantManager {
+	return &TenantManager{
+		template: *template,
+		clients:  map[string]*Headless{},
+	}
+}
+
+// Tenant returns the client for the tenant with the given ID or verified domain, creating it on
+// first use. The same client is returned for every call with the same tenant, so its credentials
+// are only requested once and then refreshed as needed. An empty tenant fails with ErrNoTenant.
+func (m *TenantManager) Tenant(tenantID string) (*Headless, error) {
+	if tenantID == "" {
+		return nil, ErrNoTenant
+	}
+	key := strings.ToLower(tenantID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h, ok := m.clients[key]; ok {
+		return h, nil
+	}
+	h := m.template
+	h.Error = nil
+	h.RefreshToken = ""
+	h.RequestCredentials = &RequestCredentials{}
+	h.TenantID = tenantID
+	m.clients[key] = &h
+	return &h, nil
+}
+
+// Tenants returns the tenants clients have been created for, sorted.
+func (m *TenantManager) Tenants() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tenants := make([]string, 0, len(m.clients))
+	for _, h := range m.clients {
+		tenants = append(tenants, h.TenantID)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// Remove drops the client of a tenant along with its credentials, such as when a customer
+// offboards. A later call to Tenant creates a new one.
+func (m *TenantManager) Remove(tenantID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, strings.ToLower(tenantID))
+}
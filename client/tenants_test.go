@@ -0,0 +1,48 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTenantManager(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		tenant := strings.Split(r.URL.Path, "/")[1]
+		w.Write([]byte(`{"access_token":"` + tenant + `","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	template := NewHeadless("app", "secret", nil)
+	template.Options = &Options{AuthorityHost: srv.URL}
+	m := NewTenantManager(template)
+
+	a, _ := m.Tenant("tenant-a")
+	b, _ := m.Tenant("tenant-b")
+	if again, _ := m.Tenant("TENANT-A"); again != a {
+		t.Fatal("expected the client of a tenant to be reused")
+	}
+	if _, err := m.Tenant(""); err != ErrNoTenant {
+		t.Fatalf("expected an empty tenant to be rejected, got %v", err)
+	}
+	for _, c := range []*Headless{a, b, a} {
+		if err := c.InitializeCredentials(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.Credentials().AccessToken != "tenant-a" || b.Credentials().AccessToken != "tenant-b" {
+		t.Fatalf("unexpected tokens %q and %q", a.Credentials().AccessToken, b.Credentials().AccessToken)
+	}
+	if len(paths) != 2 || paths[0] != "/tenant-a/oauth2/v2.0/token" || paths[1] != "/tenant-b/oauth2/v2.0/token" {
+		t.Fatalf("unexpected token requests %v", paths)
+	}
+	if template.Credentials().AccessToken != "" {
+		t.Fatal("expected the template's credentials to be left alone")
+	}
+	m.Remove("tenant-b")
+	if got := m.Tenants(); len(got) != 1 || got[0] != "tenant-a" {
+		t.Fatalf("unexpected tenants %v", got)
+	}
+}
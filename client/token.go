@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// tokenResponse is the part of a successful oauth2 token endpoint response the clients care about.
+type tokenResponse struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// TokenError is an error reported by the oauth2 endpoints, such as "invalid_grant" or
+// "authorization_pending".
+type TokenError struct {
+	Code        string
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%v: %v", e.Code, e.Description)
+}
+
+// postForm posts form to an oauth2 endpoint at endpoint with the http client configured in opts,
+// and decodes the JSON response. Errors reported by the endpoint are returned as a *TokenError.
+func postForm(ctx context.Context, opts *Options, endpoint string, form url.Values) (map[string]interface{}, error) {
+	uri, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", uri.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := opts.HTTP().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		return nil, err
+	}
+	serverErrCode, ok := data["error"].(string)
+	if ok {
+		serverErr, _ := data["error_description"].(string)
+		return nil, &TokenError{Code: serverErrCode, Description: serverErr}
+	}
+	return data, nil
+}
+
+// requestToken posts form to the oauth2 token endpoint at tokenURL with the http client configured
+// in opts, and parses the response. Errors reported by the endpoint are returned as a *TokenError.
+func requestToken(ctx context.Context, opts *Options, tokenURL string, form url.Values) (*tokenResponse, error) {
+	data, err := postForm(ctx, opts, tokenURL, form)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, ok := data["access_token"].(string)
+	if !ok || accessToken == "" {
+		return nil, fmt.Errorf("no access token found in response")
+	}
+	durationSecs, ok := data["expires_in"].(float64)
+	if !ok || durationSecs == 0 {
+		return nil, fmt.Errorf("no token duration found in response")
+	}
+	refreshToken, _ := data["refresh_token"].(string)
+	return &tokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(durationSecs) * time.Second),
+	}, nil
+}
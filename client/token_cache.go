@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheKey identifies the tokens of a TokenCache: those issued by a tenant to an application, for
+// an account and, except for the Web client, a set of scopes.
+type CacheKey struct {
+	TenantID string
+	ClientID string
+	// Account tells apart the users of delegated clients. It's empty for app-only tokens, and for
+	// delegated clients which don't set one.
+	Account string
+	// Scopes is the normalized, space-separated list of scopes the tokens were requested for. It's
+	// empty for the Web client, whose Scopes grow with IncrementalAuthorization, since its refresh
+	// token doesn't depend on them.
+	Scopes string
+}
+
+// NewCacheKey creates a CacheKey with scopes normalized, so that the order and case they are
+// listed in doesn't matter.
+func NewCacheKey(tenantID, clientID, account string, scopes []string) CacheKey {
+	var normalized []string
+	for _, s := range scopes {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			normalized = append(normalized, s)
+		}
+	}
+	sort.Strings(normalized)
+	return CacheKey{
+		TenantID: strings.ToLower(tenantID),
+		ClientID: strings.ToLower(clientID),
+		Account:  strings.ToLower(account),
+		Scopes:   strings.Join(normalized, " "),
+	}
+}
+
+// String returns the key as a single string, for caches keyed by strings.
+func (k CacheKey) String() string {
+	return k.TenantID + "|" + k.ClientID + "|" + k.Account + "|" + k.Scopes
+}
+
+// CachedToken is what a TokenCache holds for a CacheKey.
+type CachedToken struct {
+	AccessToken  string    `json:"accessToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	// Scopes is the normalized, space-separated list of scopes AccessToken was requested for, when
+	// the CacheKey doesn't hold them.
+	Scopes string `json:"scopes,omitempty"`
+}
+
+// TokenCache persists tokens between runs, so users don't have to sign in again and
+// applications don't request a token they already have. Set Options.TokenCache to have a client
+// read and write through one. Implement it to keep tokens in your own secret store.
+type TokenCache interface {
+	// LoadToken returns the token saved under key, or nil if there is none.
+	LoadToken(ctx context.Context, key CacheKey) (*CachedToken, error)
+
+	// SaveToken saves the token under key, replacing whatever was saved before. Since refresh
+	// tokens are rotated when used, the previous refresh token must not survive a partial write.
+	SaveToken(ctx context.Context, key CacheKey, token *CachedToken) error
+
+	// DeleteToken removes the token saved under key, if any.
+	DeleteToken(ctx context.Context, key CacheKey) error
+}
+
+// MemoryTokenCache is a TokenCache which keeps tokens in memory, to share them between clients
+// of a single process. It's safe for concurrent use.
+type MemoryTokenCache struct {
+	mu     sync.Mutex
+	tokens map[CacheKey]CachedToken
+}
+
+// NewMemoryTokenCache creates an empty MemoryTokenCache.
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{tokens: map[CacheKey]CachedToken{}}
+}
+
+// LoadToken conforms to the TokenCache interface.
+func (m *MemoryTokenCache) LoadToken(ctx context.Context, key CacheKey) (*CachedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// SaveToken conforms to the TokenCache interface.
+func (m *MemoryTokenCache) SaveToken(ctx context.Context, key CacheKey, token *CachedToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[key] = *token
+	return nil
+}
+
+// DeleteToken conforms to the TokenCache interface.
+func (m *MemoryTokenCache) DeleteToken(ctx context.Context, key CacheKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, key)
+	return nil
+}
+
+// FileTokenCache is a TokenCache which keeps tokens in a file, encrypted with AES-GCM under a key
+// supplied by the caller, such as one kept in the OS keychain. Every write replaces the file
+// atomically, so it never holds a mix of old and new tokens. It's safe for concurrent use within
+// a process; processes sharing a file may lose each other's updates, but never corrupt it.
+type FileTokenCache struct {
+	path string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewFileTokenCache creates a FileTokenCache keeping its tokens at path, encrypted with key, which
+// must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256. The file is created on
+// the first write.
+func NewFileTokenCache(path string, key []byte) (*FileTokenCache, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &FileTokenCache{path: path, aead: aead}, nil
+}
+
+// LoadToken conforms to the TokenCache interface.
+func (f *FileTokenCache) LoadToken(ctx context.Context, key CacheKey) (*CachedToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[key.String()]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// SaveToken conforms to the TokenCache interface.
+func (f *FileTokenCache) SaveToken(ctx context.Context, key CacheKey, token *CachedToken) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	tokens[key.String()] = *token
+	return f.write(tokens)
+}
+
+// DeleteToken conforms to the TokenCache interface.
+func (f *FileTokenCache) DeleteToken(ctx context.Context, key CacheKey) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key.String()]; !ok {
+		return nil
+	}
+	delete(tokens, key.String())
+	return f.write(tokens)
+}
+
+// read decrypts the tokens in the file. A missing file holds no tokens.
+func (f *FileTokenCache) read() (map[string]CachedToken, error) {
+	tokens := map[string]CachedToken{}
+	b, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	size := f.aead.NonceSize()
+	if len(b) < size {
+		return nil, fmt.Errorf("token cache %v is corrupt", f.path)
+	}
+	plain, err := f.aead.Open(nil, b[:size], b[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt token cache %v: %v", f.path, err)
+	}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// write encrypts tokens to a temporary file next to the cache, and renames it over the cache.
+func (f *FileTokenCache) write(tokens map[string]CachedToken) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(f.aead.Seal(nonce, nonce, plain, nil)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// loadToken fills creds from the token cache in opts, if any, and returns the cached refresh
+// token. Access tokens which are about to expire are left out, as is the one creds already hold,
+// since it was invalidated, and one requested for fewer than scopes, as listed in CachedToken.
+func loadToken(ctx context.Context, opts *Options, key CacheKey, creds *RequestCredentials, scopes string) (string, error) {
+	if opts == nil || opts.TokenCache == nil {
+		return "", nil
+	}
+	token, err := opts.TokenCache.LoadToken(ctx, key)
+	if err != nil || token == nil {
+		return "", err
+	}
+	current, _ := creds.Token()
+	if token.AccessToken != "" && token.AccessToken != current && time.Now().Add(opts.RenewBefore()).Before(token.ExpiresAt) && covers(token.Scopes, scopes) {
+		creds.SetToken(token.AccessToken, token.ExpiresAt)
+	}
+	return token.RefreshToken, nil
+}
+
+// saveToken saves creds along with refreshToken, and the scopes the access token was requested for
+// when key doesn't hold them, to the token cache in opts, if any.
+func saveToken(ctx context.Context, opts *Options, key CacheKey, creds *RequestCredentials, refreshToken string, scopes string) error {
+	if opts == nil || opts.TokenCache == nil {
+		return nil
+	}
+	accessToken, expiresAt := creds.Token()
+	return opts.TokenCache.SaveToken(ctx, key, &CachedToken{
+		AccessToken:  accessToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+		Scopes:       scopes,
+	})
+}
+
+// covers reports whether the normalized scopes in have include every one of want.
+func covers(have, want string) bool {
+	granted := strings.Fields(have)
+	for _, s := range strings.Fields(want) {
+		if !containsFold(granted, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInvalidGrant reports whether err is the token endpoint rejecting a grant, such as a refresh
+// token which was revoked or expired.
+func isInvalidGrant(err error) bool {
+	var tokenErr *TokenError
+	return errors.As(err, &tokenErr) && tokenErr.Code == "invalid_grant"
+}
+
+// deleteToken removes the token saved under key from the token cache in opts, if any, after the
+// token endpoint rejected it with cause, so that it isn't offered again. It returns cause.
+func deleteToken(ctx context.Context, opts *Options, key CacheKey, cause error) error {
+	if opts == nil || opts.TokenCache == nil {
+		return cause
+	}
+	if err := opts.TokenCache.DeleteToken(ctx, key); err != nil {
+		return fmt.Errorf("%w (removing the rejected token from the token cache failed: %v)", cause, err)
+	}
+	return cause
+}
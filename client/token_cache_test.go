@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "msgoraph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens")
+	key := bytes.Repeat([]byte{1}, 32)
+	cache, err := NewFileTokenCache(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	k := NewCacheKey("Tenant", "app", "", []string{"User.Read", "offline_access"})
+	if k != NewCacheKey("tenant", "APP", "", []string{"offline_access", "user.read"}) {
+		t.Fatal("expected keys to be normalized")
+	}
+	if token, err := cache.LoadToken(ctx, k); token != nil || err != nil {
+		t.Fatalf("expected an empty cache, got %v, %v", token, err)
+	}
+	if err := cache.SaveToken(ctx, k, &CachedToken{AccessToken: "access", RefreshToken: "refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("refresh")) {
+		t.Fatal("expected the cache file to be encrypted")
+	}
+	reopened, _ := NewFileTokenCache(path, key)
+	if token, err := reopened.LoadToken(ctx, k); err != nil || token == nil || token.RefreshToken != "refresh" {
+		t.Fatalf("unexpected token %v, %v", token, err)
+	}
+	wrongKey, _ := NewFileTokenCache(path, bytes.Repeat([]byte{2}, 32))
+	if _, err := wrongKey.LoadToken(ctx, k); err == nil {
+		t.Fatal("expected the cache not to decrypt with another key")
+	}
+	if err := cache.DeleteToken(ctx, k); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := reopened.LoadToken(ctx, k); token != nil {
+		t.Fatalf("expected the token to be deleted, got %v", token)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("expected temporary files to be cleaned up, found %v files", len(files))
+	}
+}
+
+func TestWebTokenCache(t *testing.T) {
+	var refreshTokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		refreshTokens = append(refreshTokens, r.Form.Get("refresh_token"))
+		w.Write([]byte(`{"access_token":"access","refresh_token":"rotated","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	cache := NewMemoryTokenCache()
+	opts := &Options{AuthorityHost: srv.URL, TokenCache: cache}
+	w := NewWeb("tenant", "app", "", "", []string{"User.Read"})
+	w.Options = opts
+	cache.SaveToken(context.Background(), w.cacheKey(), &CachedToken{
+		AccessToken:  "stale",
+		ExpiresAt:    time.Now().Add(-time.Minute),
+		RefreshToken: "saved",
+	})
+
+	if err := w.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if w.Credentials().AccessToken != "access" || len(refreshTokens) != 1 || refreshTokens[0] != "saved" {
+		t.Fatalf("expected the saved refresh token to be redeemed, got %q with %v", w.Credentials().AccessToken, refreshTokens)
+	}
+	token, _ := cache.LoadToken(context.Background(), w.cacheKey())
+	if token == nil || token.RefreshToken != "rotated" || token.AccessToken != "access" {
+		t.Fatalf("expected the rotated refresh token to be saved, got %+v", token)
+	}
+
+	next := NewWeb("tenant", "app", "", "", []string{"User.Read"})
+	next.Options = opts
+	if err := next.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if next.Credentials().AccessToken != "access" || len(refreshTokens) != 1 {
+		t.Fatal("expected the cached access token to be used")
+	}
+}
+
+func TestRejectedTokenIsDeleted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant","error_description":"AADSTS70008: The refresh token has expired"}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	saved := &CachedToken{AccessToken: "stale", ExpiresAt: time.Now().Add(-time.Minute), RefreshToken: "revoked"}
+
+	web := NewWeb("tenant", "app", "", "", []string{"User.Read"})
+	device := NewDeviceCode("tenant", "app", scopes.Scopes{scopes.DelegatedUserRead}, nil)
+	headless := NewHeadless("app", "secret", nil)
+	headless.TenantID = "tenant"
+	clients := []struct {
+		name    string
+		opts    **Options
+		key     func() CacheKey
+		refresh func() error
+		token   *string
+	}{
+		{"web", &web.Options, web.cacheKey, web.RefreshCredentials, &web.RefreshToken},
+		{"device code", &device.Options, device.cacheKey, device.RefreshCredentials, &device.RefreshToken},
+		{"headless", &headless.Options, func() CacheKey { return headless.cacheKey("tenant") }, headless.RefreshCredentials, &headless.RefreshToken},
+	}
+	for _, c := range clients {
+		cache := NewMemoryTokenCache()
+		*c.opts = &Options{AuthorityHost: srv.URL, TokenCache: cache}
+		cache.SaveToken(ctx, c.key(), saved)
+		if err := c.refresh(); !isInvalidGrant(err) {
+			t.Fatalf("%v: expected invalid_grant, got %v", c.name, err)
+		}
+		if token, _ := cache.LoadToken(ctx, c.key()); token != nil || *c.token != "" {
+			t.Fatalf("%v: expected the rejected token to be deleted, got %+v and %q", c.name, token, *c.token)
+		}
+	}
+}
+
+func TestWebTokenCacheKeepsRefreshTokenAcrossScopes(t *testing.T) {
+	var refreshTokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		refreshTokens = append(refreshTokens, r.Form.Get("refresh_token"))
+		w.Write([]byte(`{"access_token":"mail","refresh_token":"rotated","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	cache := NewMemoryTokenCache()
+	opts := &Options{AuthorityHost: srv.URL, TokenCache: cache}
+	w := NewWeb("tenant", "app", "", "", []string{"User.Read"})
+	w.Options = opts
+	w.RequestCredentials.SetToken("user", time.Now().Add(time.Hour))
+	if err := saveToken(ctx, opts, w.cacheKey(), w.RequestCredentials, "saved", w.scopeKey()); err != nil {
+		t.Fatal(err)
+	}
+
+	key := w.cacheKey()
+	if w.IncrementalAuthorization(scopes.Scopes{scopes.DelegatedMailRead}) == "" || w.cacheKey() != key {
+		t.Fatalf("expected adding scopes to keep the cache key, got %+v and %+v", key, w.cacheKey())
+	}
+	next := NewWeb("tenant", "app", "", "", []string{"User.Read", "Mail.Read"})
+	next.Options = opts
+	if err := next.RefreshCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if next.Credentials().AccessToken != "mail" || len(refreshTokens) != 1 || refreshTokens[0] != "saved" {
+		t.Fatalf("expected the saved refresh token to be redeemed for the added scopes, got %q with %v", next.Credentials().AccessToken, refreshTokens)
+	}
+}
@@ -1,17 +1,17 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"net"
 	"net/url"
 	"strings"
-	"time"
-)
 
-var (
-	authURL = "https://login.microsoftonline.com/%v/oauth2/v2.0/authorize"
+	"github.com/cention-mujibur-rahman/msgoraph/scopes"
 )
 
 // Web is used to authenticate requests in the context of an online/user-facing app, such
@@ -21,17 +21,28 @@ var (
 // then the code would be sent to the backend for the setAccessToken() part, given that that part
 // does require an ApplicationSecret. Be sure to specify DelegatedOfflineAccess as a scope if you
 // want refreshing to work.
+//
+// Every login attempt started with Authorization or Auth gets a random State, which the callback
+// is checked against, and a PKCE CodeVerifier, which is sent when the code is redeemed. Public
+// clients, such as desktop apps, can leave ApplicationSecret empty.
+//
+// With Options.TokenCache set, the tokens are saved under Account, and InitializeCredentials picks
+// them up without an AuthorizationCode, so users only sign in again once the refresh token expires.
 type Web struct {
 	TenantID           string
+	Account            string
 	ApplicationID      string
 	ApplicationSecret  string
 	AuthorizationCode  string
+	CodeVerifier       string
 	Error              error
 	LocalhostPort      int
+	Options            *Options
 	RedirectURI        string
 	RefreshToken       string
 	RequestCredentials *RequestCredentials
 	Scopes             string
+	State              string
 }
 
 // NewWeb creates a new client.Web connection. To initialize the authentication on this, call
@@ -54,210 +65,256 @@ func (w *Web) Credentials() *RequestCredentials {
 	return w.RequestCredentials
 }
 
+// RequestOptions returns the Options used for requests made with this client. Conforms to the
+// client.Configured interface.
+func (w *Web) RequestOptions() *Options {
+	return w.Options
+}
+
 // InitializeCredentials starts an oauth login flow to retrieve an authorization code, then exchange
 // that authorization code for an access token and (if offline access is enabled) a refresh token.
 func (w *Web) InitializeCredentials() error {
-	err := w.setAccessToken()
-	return err
+	return w.InitializeCredentialsWithContext(context.Background())
 }
 
-// InitializeAuth starts an oauth login flow to retrieve an authorization code, then exchange
-// that authorization code for an access token and (if offline access is enabled) a refresh token.
-func (w *Web) Auth() string {
-	return w.setAuthorizationCode()
+// InitializeCredentialsWithContext is the same as InitializeCredentials, with the token request
+// bound to ctx.
+func (w *Web) InitializeCredentialsWithContext(ctx context.Context) error {
+	return w.setAccessToken(ctx)
 }
 
-func (w *Web) localServer() *http.Server {
-	srv := &http.Server{Addr: fmt.Sprintf(":%v", w.LocalhostPort)}
-	http.HandleFunc("/login", func(wr http.ResponseWriter, r *http.Request) {
-		err := r.ParseForm()
-		if err != nil {
-			w.Error = fmt.Errorf("Error while parsing form from response %s", err)
-			return
-		}
-		if v, ok := r.Form["error"]; ok && len(v) > 0 {
-			errorDescription, ok := r.Form["error_description"]
-			if ok && len(errorDescription) > 0 {
-				err = fmt.Errorf("%v: %v", strings.Join(v, ""), errorDescription)
-				fmt.Fprintf(wr, "%v", err)
-				w.Error = err
-			} else {
-				err = fmt.Errorf("%v", strings.Join(v, ""))
-				fmt.Fprintf(wr, "%v", err)
-				w.Error = err
-			}
-			return
-		}
-		code, codeOk := r.Form["code"]
-		if len(code) > 0 && codeOk {
-			fmt.Fprintf(wr, "authorization done. you may close this window now")
-			w.AuthorizationCode = strings.Join(code, "")
-			return
-		}
-		err = fmt.Errorf("error getting authorization code from login response")
-		fmt.Fprintf(wr, "%v", err)
-		w.Error = err
-	})
-	go func() {
-		if err := srv.ListenAndServe(); err != nil {
-			// This will throw an error when we shutdown the server during the normal authorization flow
-			// So we try to catch that error, and only return the real error if it isn't the expected
-			// error.
-			if !strings.Contains(err.Error(), "Server closed") {
-				w.Error = fmt.Errorf("error on ListenAndServe: %v", err)
-			}
-		}
-	}()
-	return srv
+// Auth starts a new login attempt and returns the url to send the user to, the same as
+// Authorization. On failure it returns "" and sets Error.
+func (w *Web) Auth() string {
+	return w.authorization("")
 }
 
 //Authorization Request an authorization code
 //The authorization code flow begins with the client directing the user to the /authorize endpoint.
+//Each call starts a new login attempt, with a new State and CodeVerifier.
 //
 //https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow
 func (w *Web) Authorization() string {
-	return fmt.Sprintf("https://login.microsoftonline.com/%v/oauth2/v2.0/authorize?response_mode=query&state=12345&response_type=code&client_id=%v&scope=%v&redirect_uri=%v", w.TenantID, w.ApplicationID, strings.ReplaceAll(w.Scopes, ",", "%20"), w.RedirectURI)
+	return w.authorization("")
 }
 
-func (w *Web) redirectURI() string {
-	return fmt.Sprintf("http://localhost:%v/login", w.LocalhostPort)
+// IncrementalAuthorization adds permissions to Scopes, and starts a login attempt which asks the
+// user to consent to them, for when a feature needs more permissions than the user granted so far.
+func (w *Web) IncrementalAuthorization(permissions scopes.Scopes) string {
+	list := w.scopeList()
+	for _, p := range permissionNames(permissions) {
+		if !containsFold(list, p) {
+			list = append(list, p)
+		}
+	}
+	w.Scopes = strings.Join(list, ",")
+	return w.authorization(PromptConsent)
 }
 
-// RefreshCredentials will attempt to refresh the access token if it is expired. This call will fail
-// if the original authorization was not made with a Offline scope provided.
-func (w *Web) RefreshCredentials() error {
-	if w.RefreshToken == "" {
-		return fmt.Errorf("client.Web: no refresh token found in web client. call client.InitializeCredentials to fill this")
+// authorization starts a new login attempt and returns its authorize url.
+func (w *Web) authorization(prompt string) string {
+	if err := w.newLoginAttempt(); err != nil {
+		w.Error = err
+		return ""
 	}
-	w.RequestCredentials.AccessTokenUpdating.Lock()
-	defer w.RequestCredentials.AccessTokenUpdating.Unlock()
-
-	turl := fmt.Sprintf("https://login.microsoftonline.com/%v/oauth2/v2.0/token", w.TenantID)
-	tokenURI, err := url.Parse(turl)
-	if err != nil {
-		return err
+	r := &ConsentRequest{
+		TenantID:      w.TenantID,
+		ApplicationID: w.ApplicationID,
+		RedirectURI:   w.RedirectURI,
+		Prompt:        prompt,
+		State:         w.State,
+		Options:       w.Options,
 	}
-
-	resp, err := http.PostForm(tokenURI.String(), url.Values{
-		"client_id":     {w.ApplicationID},
-		"client_secret": {w.ApplicationSecret},
-		"grant_type":    {"refresh_token"},
-		"refresh_token": {w.RefreshToken},
+	return r.authorizeURL(w.scopeList(), url.Values{
+		"code_challenge":        {codeChallenge(w.CodeVerifier)},
+		"code_challenge_method": {"S256"},
 	})
-	if err != nil {
-		return err
+}
+
+// HandleCallback takes the query parameters the authorization endpoint redirected the user back
+// with, and keeps the authorization code they carry, once the state has been checked against the
+// one of the current login attempt. The state can only be used once. An error the authorization
+// endpoint redirected back with is returned as a *TokenError, but only once the state checks out,
+// so a forged redirect can't end the login attempt.
+func (w *Web) HandleCallback(params url.Values) error {
+	if w.State == "" || subtle.ConstantTimeCompare([]byte(params.Get("state")), []byte(w.State)) != 1 {
+		return fmt.Errorf("client.Web: state mismatch in login response")
+	}
+	if code := params.Get("error"); code != "" {
+		w.State = ""
+		return &TokenError{Code: code, Description: params.Get("error_description")}
 	}
-	b, err := ioutil.ReadAll(resp.Body)
+	code := params.Get("code")
+	if code == "" {
+		return fmt.Errorf("error getting authorization code from login response")
+	}
+	w.State = ""
+	w.AuthorizationCode = code
+	return nil
+}
+
+// newLoginAttempt generates a new State and CodeVerifier.
+func (w *Web) newLoginAttempt() error {
+	state, err := randomString(16)
 	if err != nil {
 		return err
 	}
-	var data map[string]interface{}
-	err = json.Unmarshal(b, &data)
+	verifier, err := randomString(32)
 	if err != nil {
 		return err
 	}
-	serverErrCode, ok := data["error"].(string)
-	if ok {
-		serverErr, ok := data["error_description"].(string)
-		if ok {
-			return fmt.Errorf("%v: %v", serverErrCode, serverErr)
-		}
-		return fmt.Errorf(serverErrCode)
-	}
-	accessToken, ok := data["access_token"].(string)
-	if !ok || accessToken == "" {
-		return fmt.Errorf("no access token found in response")
-	}
-	durationSecs, ok := data["expires_in"].(float64)
-	if !ok || durationSecs == 0 {
-		return fmt.Errorf("no token duration found in response")
-	}
-	refreshToken, ok := data["refresh_token"].(string)
-	if !ok || refreshToken == "" {
-		return fmt.Errorf("no refresh token found in response")
-	}
-	expiresAt := time.Now().Add(time.Duration(durationSecs) * time.Second)
-	w.RequestCredentials.AccessToken = accessToken
-	w.RequestCredentials.AccessTokenExpiresAt = expiresAt
-	w.RefreshToken = refreshToken
+	w.State, w.CodeVerifier = state, verifier
 	return nil
 }
 
-func (w *Web) setAccessToken() error {
-	if w.AuthorizationCode == "" {
-		return fmt.Errorf("client.Web: no access code found in web client")
+// randomString returns n random bytes, base64url encoded.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge returns the S256 PKCE code challenge of verifier.
+//
+// https://tools.ietf.org/html/rfc7636#section-4.2
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// scopeList splits the comma or space separated Scopes.
+func (w *Web) scopeList() []string {
+	return strings.FieldsFunc(w.Scopes, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// redirectURI returns the redirect uri of the login server started by InteractiveLogin, listening
+// on addr.
+func (w *Web) redirectURI(addr net.Addr) string {
+	return fmt.Sprintf("http://%v/login", addr)
+}
+
+// RefreshCredentials will attempt to refresh the access token if it is expired. This call will fail
+// if the original authorization was not made with a Offline scope provided.
+func (w *Web) RefreshCredentials() error {
+	return w.RefreshCredentialsWithContext(context.Background())
+}
+
+// RefreshCredentialsWithContext is the same as RefreshCredentials, with the token request bound
+// to ctx. The refresh token is looked up in Options.TokenCache when the client doesn't have one.
+func (w *Web) RefreshCredentialsWithContext(ctx context.Context) error {
 	w.RequestCredentials.AccessTokenUpdating.Lock()
 	defer w.RequestCredentials.AccessTokenUpdating.Unlock()
-	if w.RequestCredentials.AccessToken != "" && w.RequestCredentials.AccessTokenExpiresAt.After(time.Now()) {
+	if w.RequestCredentials.Valid(w.Options.RenewBefore()) {
 		return nil
 	}
-	turl := fmt.Sprintf("https://login.microsoftonline.com/%v/oauth2/v2.0/token", w.TenantID)
-	tokenURI, err := url.Parse(turl)
-	if err != nil {
-		return err
+	if w.RefreshToken == "" {
+		refreshToken, err := loadToken(ctx, w.Options, w.cacheKey(), w.RequestCredentials, w.scopeKey())
+		if err != nil {
+			return err
+		}
+		w.RefreshToken = refreshToken
+		if w.RequestCredentials.Valid(w.Options.RenewBefore()) {
+			return nil
+		}
+	}
+	if w.RefreshToken == "" {
+		return fmt.Errorf("client.Web: no refresh token found in web client. call client.InitializeCredentials to fill this")
 	}
+	return w.refresh(ctx)
+}
 
-	resp, err := http.PostForm(tokenURI.String(), url.Values{
-		"client_id":     {w.ApplicationID},
-		"client_secret": {w.ApplicationSecret},
-		"code":          {w.AuthorizationCode},
-		"grant_type":    {"authorization_code"},
-		"redirect_uri":  {w.RedirectURI},
-	})
-	if err != nil {
-		return err
+// refresh redeems the refresh token. One the token endpoint rejects, because it was revoked or
+// expired, is forgotten and removed from Options.TokenCache. The caller holds AccessTokenUpdating.
+func (w *Web) refresh(ctx context.Context) error {
+	turl := fmt.Sprintf("%v%v/oauth2/v2.0/token", w.Options.Authority(), w.TenantID)
+	token, err := requestToken(ctx, w.Options, turl, w.form(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {w.RefreshToken},
+	}))
+	if isInvalidGrant(err) {
+		w.RefreshToken = ""
+		return deleteToken(ctx, w.Options, w.cacheKey(), err)
 	}
-	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
-	var data map[string]interface{}
-	err = json.Unmarshal(b, &data)
-	if err != nil {
-		return err
+	if token.RefreshToken == "" {
+		return fmt.Errorf("no refresh token found in response")
 	}
-	serverErrCode, ok := data["error"].(string)
-	if ok {
-		serverErr, ok := data["error_description"].(string)
-		if ok {
-			return fmt.Errorf("%v: %v", serverErrCode, serverErr)
+	w.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	w.RefreshToken = token.RefreshToken
+	return saveToken(ctx, w.Options, w.cacheKey(), w.RequestCredentials, w.RefreshToken, w.scopeKey())
+}
+
+// setAccessToken redeems the authorization code, and clears it since it can't be redeemed again.
+// Without one, the credentials saved in Options.TokenCache by an earlier run are used if there are
+// any.
+func (w *Web) setAccessToken(ctx context.Context) error {
+	w.RequestCredentials.AccessTokenUpdating.Lock()
+	defer w.RequestCredentials.AccessTokenUpdating.Unlock()
+	if w.RequestCredentials.Valid(w.Options.RenewBefore()) {
+		return nil
+	}
+	if w.AuthorizationCode == "" {
+		refreshToken, err := loadToken(ctx, w.Options, w.cacheKey(), w.RequestCredentials, w.scopeKey())
+		if err != nil {
+			return err
+		}
+		if w.RefreshToken == "" {
+			w.RefreshToken = refreshToken
+		}
+		if w.RequestCredentials.Valid(w.Options.RenewBefore()) {
+			return nil
 		}
-		return fmt.Errorf(serverErrCode)
+		if w.RefreshToken != "" {
+			return w.refresh(ctx)
+		}
+		return fmt.Errorf("client.Web: no access code found in web client")
 	}
-	accessToken, ok := data["access_token"].(string)
-	if !ok || accessToken == "" {
-		return fmt.Errorf("no access token found in response")
+	turl := fmt.Sprintf("%v%v/oauth2/v2.0/token", w.Options.Authority(), w.TenantID)
+	form := w.form(url.Values{
+		"code":         {w.AuthorizationCode},
+		"grant_type":   {"authorization_code"},
+		"redirect_uri": {w.RedirectURI},
+	})
+	if w.CodeVerifier != "" {
+		form.Set("code_verifier", w.CodeVerifier)
 	}
-	durationSecs, ok := data["expires_in"].(float64)
-	if !ok || durationSecs == 0 {
-		return fmt.Errorf("no token duration found in response")
+	token, err := requestToken(ctx, w.Options, turl, form)
+	if err != nil {
+		return err
 	}
+	w.AuthorizationCode = ""
 	//if w.Scopes.HasScope(scopes.DelegatedOfflineAccess) {
-	refreshToken, ok := data["refresh_token"].(string)
-	if !ok || refreshToken == "" {
+	if token.RefreshToken == "" {
 		return fmt.Errorf("no refresh token found in response")
 	}
-	w.RefreshToken = refreshToken
+	w.RefreshToken = token.RefreshToken
 	//}
-	expiresAt := time.Now().Add(time.Duration(durationSecs) * time.Second)
-	w.RequestCredentials.AccessToken = accessToken
-	w.RequestCredentials.AccessTokenExpiresAt = expiresAt
-	return nil
+	w.RequestCredentials.SetToken(token.AccessToken, token.ExpiresAt)
+	return saveToken(ctx, w.Options, w.cacheKey(), w.RequestCredentials, w.RefreshToken, w.scopeKey())
+}
+
+// cacheKey returns the key of the client's tokens in a TokenCache. It leaves out the Scopes, so
+// that the refresh token is still found once IncrementalAuthorization adds to them.
+func (w *Web) cacheKey() CacheKey {
+	return NewCacheKey(w.TenantID, w.ApplicationID, w.Account, nil)
 }
-func (w *Web) setAuthorizationCode() string {
-	formVals := url.Values{}
-	formVals.Set("client_id", w.ApplicationID)
-	formVals.Set("grant_type", "authorization_code")
-	formVals.Set("redirect_uri", w.RedirectURI)
-	formVals.Set("response_mode", "query")
-	formVals.Set("response_type", "code")
-	formVals.Set("scope", w.Scopes)
-	uri, err := url.Parse("https://login.microsoftonline.com/common/oauth2/v2.0/authorize")
-	if err != nil {
-		return "something wrong"
-	}
-	uri.RawQuery = formVals.Encode()
 
-	return uri.String()
+// scopeKey returns the Scopes normalized as in a CacheKey.
+func (w *Web) scopeKey() string {
+	return NewCacheKey("", "", "", w.scopeList()).Scopes
+}
+
+// form adds the client credentials to the form of a token request. Public clients have no secret.
+func (w *Web) form(form url.Values) url.Values {
+	form.Set("client_id", w.ApplicationID)
+	if w.ApplicationSecret != "" {
+		form.Set("client_secret", w.ApplicationSecret)
+	}
+	return form
 }
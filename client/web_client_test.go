@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestWebPKCEAndState(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.Form
+		w.Write([]byte(`{"access_token":"token","refresh_token":"refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	w := NewWeb("tenant", "app", "", "http://localhost/login", []string{"User.Read"})
+	w.Options = &Options{AuthorityHost: srv.URL}
+
+	u, err := url.Parse(w.Authorization())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/tenant/oauth2/v2.0/authorize" {
+		t.Fatalf("expected the tenant's authorize endpoint, got %v", u)
+	}
+	q := u.Query()
+	sum := sha256.Sum256([]byte(w.CodeVerifier))
+	if q.Get("state") == "" || q.Get("state") != w.State {
+		t.Fatalf("unexpected state %q", q.Get("state"))
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected code challenge %q", q.Get("code_challenge"))
+	}
+	if first := w.State; w.Authorization() == "" || w.State == first {
+		t.Fatal("expected a new state for every login attempt")
+	}
+	if auth, _ := url.Parse(w.Auth()); auth.Path != u.Path || auth.Query().Get("state") != w.State {
+		t.Fatalf("expected Auth to start a login at the tenant's authorize endpoint, got %v", auth)
+	}
+
+	if err := w.HandleCallback(url.Values{"state": {"forged"}, "code": {"code"}}); err == nil {
+		t.Fatal("expected a forged state to be rejected")
+	}
+	if err := w.HandleCallback(url.Values{"error": {"access_denied"}}); err == nil || w.State == "" {
+		t.Fatalf("expected an error without a state to be rejected without ending the attempt, got %v", err)
+	}
+	if err := w.HandleCallback(url.Values{"state": {w.State}, "code": {"code"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.HandleCallback(url.Values{"state": {q.Get("state")}, "code": {"code"}}); err == nil {
+		t.Fatal("expected a used state to be rejected")
+	}
+	if err := w.InitializeCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if form.Get("code") != "code" || form.Get("code_verifier") != w.CodeVerifier {
+		t.Fatalf("unexpected token request %v", form)
+	}
+	if _, ok := form["client_secret"]; ok {
+		t.Fatal("expected no client_secret for a public client")
+	}
+	if w.AuthorizationCode != "" {
+		t.Fatal("expected the redeemed authorization code to be cleared")
+	}
+	w.RequestCredentials = &RequestCredentials{}
+	w.RefreshToken = ""
+	form = nil
+	if err := w.InitializeCredentials(); err == nil || form != nil {
+		t.Fatalf("expected the spent authorization code not to be sent again, got %v and %v", err, form)
+	}
+}
+
+func TestWebInteractiveLogin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"token","refresh_token":"refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	w := NewWeb("tenant", "app", "", "", []string{"User.Read"})
+	w.Options = &Options{AuthorityHost: srv.URL}
+
+	replies := make(chan int, 3)
+	err := w.InteractiveLogin(context.Background(), func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		redirect := u.Query().Get("redirect_uri")
+		if r, err := url.Parse(redirect); err != nil || r.Hostname() != "127.0.0.1" {
+			t.Errorf("expected the redirect uri to match the login server address, got %q", redirect)
+		}
+		go func() {
+			callbacks := []string{
+				"error=access_denied&state=forged",
+				"code=code&state=stale",
+				"code=code&state=" + u.Query().Get("state"),
+			}
+			for _, query := range callbacks {
+				resp, err := http.Get(redirect + "?" + query)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				resp.Body.Close()
+				replies <- resp.StatusCode
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.RequestCredentials.AccessToken != "token" || w.AuthorizationCode != "" {
+		t.Fatalf("unexpected credentials %+v", w.RequestCredentials)
+	}
+	if forged, stale, ok := <-replies, <-replies, <-replies; forged != http.StatusBadRequest || stale != http.StatusBadRequest || ok != http.StatusOK {
+		t.Fatalf("unexpected callback responses %v, %v and %v", forged, stale, ok)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	w.RequestCredentials = &RequestCredentials{}
+	w.RefreshToken = ""
+	if err := w.InteractiveLogin(ctx, func(string) error { return nil }); err != context.DeadlineExceeded {
+		t.Fatalf("expected the login to time out, got %v", err)
+	}
+}
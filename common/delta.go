@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrDeltaLinkExpired is reported by a delta query resumed from a delta link Graph no longer
+// accepts, because it expired or the changes since have to be resynced. The link has been removed
+// from the DeltaTokenStore, so the next query under the same key starts over with a full sync.
+var ErrDeltaLinkExpired = errors.New("delta link expired; a full sync is required")
+
+// ChangeType tells apart the entries yielded by a delta query.
+type ChangeType string
+
+const (
+	// ChangeAdded is reported for every object of an initial delta query, one which wasn't resumed
+	// from a saved delta link.
+	ChangeAdded ChangeType = "added"
+	// ChangeUpdated is reported for objects which were created or changed since the delta link a
+	// query was resumed from. Graph doesn't tell these two apart.
+	ChangeUpdated ChangeType = "changed"
+	// ChangeRemoved is reported for objects annotated with @removed.
+	ChangeRemoved ChangeType = "removed"
+)
+
+// Removed is the @removed annotation a delta query puts on objects which left the collection. The
+// reason is "changed" when the object can still be restored, and "deleted" when it is gone for good.
+//
+// https://docs.microsoft.com/en-us/graph/delta-query-overview
+type Removed struct {
+	Reason string `json:"reason"`
+}
+
+// DeltaTokenStore persists the @odata.deltaLink of a delta query between runs, so the next run only
+// asks for what changed in the meantime. Implement it to keep delta links in your own database.
+type DeltaTokenStore interface {
+	// LoadDeltaLink returns the delta link saved under key, or "" if there is none. A bare
+	// $deltatoken value is accepted as well as a full link.
+	LoadDeltaLink(ctx context.Context, key string) (string, error)
+
+	// SaveDeltaLink saves the delta link under key, replacing whatever was saved before. An empty
+	// deltaLink removes the one saved.
+	SaveDeltaLink(ctx context.Context, key string, deltaLink string) error
+}
+
+// MemoryDeltaTokenStore is a DeltaTokenStore which keeps delta links in memory. It's safe for
+// concurrent use.
+type MemoryDeltaTokenStore struct {
+	mu    sync.Mutex
+	links map[string]string
+}
+
+// NewMemoryDeltaTokenStore creates an empty MemoryDeltaTokenStore.
+func NewMemoryDeltaTokenStore() *MemoryDeltaTokenStore {
+	return &MemoryDeltaTokenStore{links: map[string]string{}}
+}
+
+// LoadDeltaLink conforms to the DeltaTokenStore interface.
+func (m *MemoryDeltaTokenStore) LoadDeltaLink(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.links[key], nil
+}
+
+// SaveDeltaLink conforms to the DeltaTokenStore interface.
+func (m *MemoryDeltaTokenStore) SaveDeltaLink(ctx context.Context, key string, deltaLink string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if deltaLink == "" {
+		delete(m.links, key)
+		return nil
+	}
+	m.links[key] = deltaLink
+	return nil
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"encoding/json"
+)
+
+// Values of DirectoryObject.Type.
+const (
+	TypeAdministrativeUnit = "#microsoft.graph.administrativeUnit"
+	TypeDirectoryRole      = "#microsoft.graph.directoryRole"
+	TypeGroup              = "#microsoft.graph.group"
+	TypeUser               = "#microsoft.graph.user"
+)
+
+// DirectoryObject is an entry of a collection which holds several types of directory objects,
+// such as the groups, directory roles and administrative units a user is a member of. Type tells
+// them apart, and Decode reads the object into the struct of its type, such as groups.Group.
+//
+// https://docs.microsoft.com/en-us/graph/api/resources/directoryobject
+type DirectoryObject struct {
+	Type        string  `json:"@odata.type"`
+	ID          *string `json:"id"`
+	DisplayName *string `json:"displayName"`
+	Description *string `json:"description"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON keeps the whole object around for Decode.
+func (o *DirectoryObject) UnmarshalJSON(b []byte) error {
+	type plain DirectoryObject
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	o.raw = append(json.RawMessage{}, b...)
+	return nil
+}
+
+// IsGroup reports whether the object is a group.
+func (o DirectoryObject) IsGroup() bool {
+	return o.Type == TypeGroup
+}
+
+// IsDirectoryRole reports whether the object is a directory role.
+func (o DirectoryObject) IsDirectoryRole() bool {
+	return o.Type == TypeDirectoryRole
+}
+
+// IsAdministrativeUnit reports whether the object is an administrative unit.
+func (o DirectoryObject) IsAdministrativeUnit() bool {
+	return o.Type == TypeAdministrativeUnit
+}
+
+// Decode unmarshals the whole object into v.
+func (o DirectoryObject) Decode(v interface{}) error {
+	return json.Unmarshal(o.raw, v)
+}
@@ -0,0 +1,75 @@
+package common
+
+// Pager is what an Iterator reads a Graph collection from, one page at a time. It decodes items
+// into the values it's given, and stops for good once the collection runs out or an error occurs.
+type Pager interface {
+	Next(v interface{}) bool
+	NextPage(v interface{}) bool
+	Err() error
+	Count() (int64, bool)
+}
+
+// Iterator lazily walks a list of T, only fetching the next page from the Graph API once the
+// current one is used up. Stop at any point by no longer calling Next.
+//
+//	it := svc.IterateUsers(ctx, users.UserDefaultFields)
+//	for it.Next() {
+//		fmt.Println(msgoraph.StringValue(it.Item().DisplayName))
+//	}
+//	return it.Err()
+type Iterator[T any] struct {
+	pager Pager
+	item  T
+}
+
+// NewIterator creates an Iterator reading from pager.
+func NewIterator[T any](pager Pager) *Iterator[T] {
+	return &Iterator[T]{pager: pager}
+}
+
+// Next advances to the next item. It returns false when there are no more items or an error
+// occurred; check Err to tell apart.
+func (i *Iterator[T]) Next() bool {
+	var zero T
+	i.item = zero
+	return i.pager.Next(&i.item)
+}
+
+// Item returns the item Next advanced to.
+func (i *Iterator[T]) Item() T {
+	return i.item
+}
+
+// NextPage returns whatever is left of the current page, or the whole next page, of items.
+func (i *Iterator[T]) NextPage() ([]T, bool) {
+	var page []T
+	ok := i.pager.NextPage(&page)
+	return page, ok
+}
+
+// All returns every item left in the list, fetching all of the remaining pages.
+func (i *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for {
+		page, ok := i.NextPage()
+		if !ok {
+			break
+		}
+		items = append(items, page...)
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (i *Iterator[T]) Err() error {
+	return i.pager.Err()
+}
+
+// Count returns the @odata.count of the list, when it was requested and the first page has been
+// fetched.
+func (i *Iterator[T]) Count() (int64, bool) {
+	return i.pager.Count()
+}
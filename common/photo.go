@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// MaxPhotoSize is the largest profile photo, in bytes, the Graph API accepts in a single upload.
+const MaxPhotoSize = 4 << 20
+
+// ErrPhotoNotModified is returned when downloading a photo whose ETag matches the one cached, so
+// the copy the caller already has is still current.
+var ErrPhotoNotModified = errors.New("photo not modified")
+
+// ProfilePhoto describes one size of the profile photo of a user or group. The ID names the size,
+// such as "48X48", or is "default" for the largest one available.
+//
+// https://docs.microsoft.com/en-us/graph/api/resources/profilephoto?view=graph-rest-1.0
+type ProfilePhoto struct {
+	ID          string `json:"id"`
+	Height      int    `json:"height"`
+	Width       int    `json:"width"`
+	ContentType string `json:"@odata.mediaContentType"`
+	ETag        string `json:"@odata.mediaEtag"`
+}
+
+// Photo is a downloaded profile photo. Body must be closed once read.
+type Photo struct {
+	ProfilePhoto
+	Body io.ReadCloser
+}
+
+// PhotoCache keeps the metadata of the photos downloaded before, so that a photo whose ETag hasn't
+// changed isn't downloaded again. Implement it to keep the metadata next to your own copies of the
+// photos.
+type PhotoCache interface {
+	// LoadPhoto returns the metadata saved under key, or nil if there is none.
+	LoadPhoto(ctx context.Context, key string) (*ProfilePhoto, error)
+
+	// SavePhoto saves the metadata under key, replacing whatever was saved before.
+	SavePhoto(ctx context.Context, key string, photo *ProfilePhoto) error
+}
+
+// MemoryPhotoCache is a PhotoCache which keeps photo metadata in memory. It's safe for concurrent
+// use.
+type MemoryPhotoCache struct {
+	mu     sync.Mutex
+	photos map[string]ProfilePhoto
+}
+
+// NewMemoryPhotoCache creates an empty MemoryPhotoCache.
+func NewMemoryPhotoCache() *MemoryPhotoCache {
+	return &MemoryPhotoCache{photos: map[string]ProfilePhoto{}}
+}
+
+// LoadPhoto conforms to the PhotoCache interface.
+func (m *MemoryPhotoCache) LoadPhoto(ctx context.Context, key string) (*ProfilePhoto, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	photo, ok := m.photos[key]
+	if !ok {
+		return nil, nil
+	}
+	return &photo, nil
+}
+
+// SavePhoto conforms to the PhotoCache interface.
+func (m *MemoryPhotoCache) SavePhoto(ctx context.Context, key string, photo *ProfilePhoto) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.photos[key] = *photo
+	return nil
+}
@@ -1,3 +1,7 @@
 module github.com/cention-mujibur-rahman/msgoraph
 
-go 1.14
+go 1.19
+
+require software.sslmate.com/src/go-pkcs12 v0.5.0
+
+require golang.org/x/crypto v0.11.0 // indirect
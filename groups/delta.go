@@ -0,0 +1,63 @@
+package groups
+
+import (
+	"context"
+
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+// GroupChange is a single entry yielded by a groups delta query. Removed groups only carry their
+// ID and the Removed annotation.
+type GroupChange struct {
+	Group
+	Removed *common.Removed `json:"@removed"`
+}
+
+// GroupDeltaIterator walks the changes of a groups delta query. Once it's exhausted, DeltaLink
+// holds the link to pass to the next query, and it has been saved to the store the query was
+// started with.
+type GroupDeltaIterator struct {
+	it     *internal.DeltaIterator
+	change GroupChange
+}
+
+// Next advances to the next change. It returns false when there are no more changes or an error
+// occurred; check Err to tell apart.
+func (i *GroupDeltaIterator) Next() bool {
+	i.change = GroupChange{}
+	return i.it.Next(&i.change)
+}
+
+// Change returns the change Next advanced to.
+func (i *GroupDeltaIterator) Change() GroupChange {
+	return i.change
+}
+
+// ChangeType tells whether the current change is an added, changed or removed group.
+func (i *GroupDeltaIterator) ChangeType() common.ChangeType {
+	return i.it.ChangeType(i.change.Removed)
+}
+
+// DeltaLink returns the @odata.deltaLink to resume from next time, once the iterator is exhausted.
+func (i *GroupDeltaIterator) DeltaLink() string {
+	return i.it.DeltaLink()
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (i *GroupDeltaIterator) Err() error {
+	return i.it.Err()
+}
+
+// DeltaGroups runs a delta query over the groups in the tenant. If store holds a delta link under
+// key, only the groups which changed since it was saved are returned; otherwise every group is. The
+// new delta link is saved under key once the iterator is exhausted. The store may be nil, in which
+// case nothing is loaded or saved. If the saved delta link has expired, the iterator stops with
+// common.ErrDeltaLinkExpired and the link is removed, so querying again starts a full sync.
+//
+// https://docs.microsoft.com/en-us/graph/api/group-delta?view=graph-rest-1.0
+//
+// Permissions: delegated Group.Read.All, application Group.Read.All (scopes.GroupsDeltaGroups).
+func (s *ServiceContext) DeltaGroups(ctx context.Context, store common.DeltaTokenStore, key string) *GroupDeltaIterator {
+	return &GroupDeltaIterator{it: internal.NewDeltaIterator(ctx, s.client, "v1.0/groups/delta", nil, store, key)}
+}
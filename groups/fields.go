@@ -0,0 +1,27 @@
+package groups
+
+// Field names a property of the group object, such as one to clear through
+// UpdateGroupRequest.NullFields. All of these have little comments on them for the purpose of
+// supressing godoc linter warnings.
+type Field string
+
+const (
+	// FieldDescription description
+	FieldDescription Field = "description"
+	// FieldDisplayName displayName
+	FieldDisplayName Field = "displayName"
+	// FieldGroupTypes groupTypes
+	FieldGroupTypes Field = "groupTypes"
+	// FieldMailNickname mailNickname
+	FieldMailNickname Field = "mailNickname"
+	// FieldSecurityEnabled securityEnabled
+	FieldSecurityEnabled Field = "securityEnabled"
+	// FieldVisibility visibility
+	FieldVisibility Field = "visibility"
+)
+
+// PropertyName conforms to the odata.Property interface, so fields can be used directly in
+// odata.Query.Select, Filter and OrderBy.
+func (f Field) PropertyName() string {
+	return string(f)
+}
@@ -1,11 +1,13 @@
 package groups
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 
 	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
 	"github.com/cention-mujibur-rahman/msgoraph/internal"
 )
 
@@ -16,6 +18,8 @@ type ServiceContext struct {
 }
 
 // Service creates a new users.ServiceContext with the given authentication credentials.
+// Requests are sent according to the client's Options, when it has any; see client.WithOptions
+// for attaching Options to a custom client.
 func Service(client client.Client) *ServiceContext {
 	return &ServiceContext{client: client}
 }
@@ -39,14 +43,27 @@ type GetGroupResponse struct {
 // Group Create a new group as specified in the request body. You can create one of the following groups
 // documentation https://docs.microsoft.com/en-us/graph/api/group-post-groups?view=graph-rest-beta&tabs=http
 type Group struct {
-	ID           *string `json:"id"`
-	Mail         *string `json:"mail"`
-	MailNickname *string `json:"mailNickname"`
+	ID              *string  `json:"id"`
+	Description     *string  `json:"description"`
+	DisplayName     *string  `json:"displayName"`
+	GroupTypes      []string `json:"groupTypes"`
+	Mail            *string  `json:"mail"`
+	MailEnabled     *bool    `json:"mailEnabled"`
+	MailNickname    *string  `json:"mailNickname"`
+	SecurityEnabled *bool    `json:"securityEnabled"`
+	Visibility      *string  `json:"visibility"`
 }
 
 // CreateGroup creates a new groups in the tenant.
+//
+// Permissions: delegated Group.ReadWrite.All, application Group.Create (scopes.GroupsCreateGroup).
 func (s *ServiceContext) CreateGroup(createGroup CreateGroupRequest) (Group, error) {
-	body, err := internal.GraphRequest(s.client, "POST", "v1.0/groups", nil, createGroup)
+	return s.CreateGroupWithContext(context.Background(), createGroup)
+}
+
+// CreateGroupWithContext is the same as CreateGroup, with the request bound to ctx.
+func (s *ServiceContext) CreateGroupWithContext(ctx context.Context, createGroup CreateGroupRequest) (Group, error) {
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "POST", "v1.0/groups", nil, createGroup)
 	if err != nil {
 		log.Printf("Error GraphRequest %#v", err)
 		return Group{}, err
@@ -59,14 +76,61 @@ func (s *ServiceContext) CreateGroup(createGroup CreateGroupRequest) (Group, err
 	return data.Group, nil
 }
 
+// UpdateGroupRequest contains the request body to update a group. Only the fields which are set
+// are sent, so that the rest of the group is left as is. A property is cleared by listing it in
+// NullFields.
+type UpdateGroupRequest struct {
+	Description     *string  `json:"description"`
+	DisplayName     *string  `json:"displayName"`
+	GroupTypes      []string `json:"groupTypes"`
+	MailNickname    *string  `json:"mailNickname"`
+	SecurityEnabled *bool    `json:"securityEnabled"`
+	Visibility      *string  `json:"visibility"`
+
+	// NullFields lists the properties to clear, such as FieldDescription.
+	NullFields []Field `json:"-"`
+}
+
+// MarshalJSON only marshals the fields which are set, along with NullFields as null.
+func (u UpdateGroupRequest) MarshalJSON() ([]byte, error) {
+	return internal.MarshalPatch(u)
+}
+
+// UpdateGroup updates a group by id. Only the fields set in the request, and its NullFields, are
+// changed.
+//
+// https://docs.microsoft.com/en-us/graph/api/group-update?view=graph-rest-1.0
+//
+// Permissions: delegated Group.ReadWrite.All, application Group.ReadWrite.All (scopes.GroupsUpdateGroup).
+func (s *ServiceContext) UpdateGroup(groupID string, u UpdateGroupRequest) error {
+	return s.UpdateGroupWithContext(context.Background(), groupID, u)
+}
+
+// UpdateGroupWithContext is the same as UpdateGroup, with the request bound to ctx.
+func (s *ServiceContext) UpdateGroupWithContext(ctx context.Context, groupID string, u UpdateGroupRequest) error {
+	reqURL := fmt.Sprintf("v1.0/groups/%v", groupID)
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "PATCH", reqURL, nil, u)
+	if err != nil {
+		log.Printf("Error UpdateGroup GraphRequest %#v", err)
+	}
+	return err
+}
+
 // CreateGroupsTeams Create a new team from a group.
 //In order to create a team, the group must have a least one owner.
 //If the group was created less than 15 minutes ago, it's possible for the Create team call to fail with a 404 error code due to replication delays.
 //The recommended pattern is to retry the Create team call three times, with a 10 second delay between calls.
 //
 //https://docs.microsoft.com/en-us/graph/api/team-put-teams?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated Group.ReadWrite.All, application Group.Create (scopes.GroupsCreateGroupsTeams).
 func (s *ServiceContext) CreateGroupsTeams(payloadBody interface{}) (Group, error) {
-	body, err := internal.GraphRequest(s.client, "POST", "v1.0/groups", nil, payloadBody)
+	return s.CreateGroupsTeamsWithContext(context.Background(), payloadBody)
+}
+
+// CreateGroupsTeamsWithContext is the same as CreateGroupsTeams, with the request bound to ctx.
+func (s *ServiceContext) CreateGroupsTeamsWithContext(ctx context.Context, payloadBody interface{}) (Group, error) {
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "POST", "v1.0/groups", nil, payloadBody)
 	if err != nil {
 		log.Printf("Error CreateGroupsTeams GraphRequest %#v", err)
 		return Group{}, err
@@ -80,21 +144,33 @@ func (s *ServiceContext) CreateGroupsTeams(payloadBody interface{}) (Group, erro
 }
 
 // GetGroupsTeams Get all groups.
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.GroupsGetGroupsTeams).
 func (s *ServiceContext) GetGroupsTeams() ([]Group, error) {
-	body, err := internal.GraphRequest(s.client, "GET", "v1.0/groups", nil, nil)
+	return s.GetGroupsTeamsWithContext(context.Background())
+}
+
+// GetGroupsTeamsWithContext is the same as GetGroupsTeams, with the request bound to ctx.
+func (s *ServiceContext) GetGroupsTeamsWithContext(ctx context.Context) ([]Group, error) {
+	groups, err := s.IterateGroupsTeams(ctx).All()
 	if err != nil {
 		log.Printf("Error GetGroupsTeams GraphRequest %#v", err)
 		return nil, err
 	}
-	var data GetAllGroupResponse
-	err = json.Unmarshal(body, &data)
-	if err != nil {
-		return nil, err
-	}
-	return data.Value, nil
+	return groups, nil
+}
+
+// IterateGroupsTeams returns an iterator over all groups. Pages are only requested as the iterator
+// advances.
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.GroupsIterateGroupsTeams).
+func (s *ServiceContext) IterateGroupsTeams(ctx context.Context) *GroupIterator {
+	return common.NewIterator[Group](internal.NewPageIterator(ctx, s.client, "v1.0/groups", nil))
 }
 
 // GetAllGroupResponse is the response to expect on a GetGroup Request.
+//
+// Deprecated: the list methods follow @odata.nextLink themselves; use IterateGroupsTeams to walk the pages.
 type GetAllGroupResponse struct {
 	Context string `json:"@odata.context"`
 	Value   []Group
@@ -104,22 +180,34 @@ type GetAllGroupResponse struct {
 //If successful, this method returns a 200 OK response code and collection of Channel objects in the response body.
 //
 //https://docs.microsoft.com/en-us/graph/api/channel-list?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated Channel.ReadBasic.All, application Channel.ReadBasic.All (scopes.GroupsGetGroupsChannels).
 func (s *ServiceContext) GetGroupsChannels(groupID string) ([]Channel, error) {
-	url := fmt.Sprintf("v1.0/teams/%v/channels", groupID)
-	body, err := internal.GraphRequest(s.client, "GET", url, nil, nil)
+	return s.GetGroupsChannelsWithContext(context.Background(), groupID)
+}
+
+// GetGroupsChannelsWithContext is the same as GetGroupsChannels, with the request bound to ctx.
+func (s *ServiceContext) GetGroupsChannelsWithContext(ctx context.Context, groupID string) ([]Channel, error) {
+	channels, err := s.IterateGroupsChannels(ctx, groupID).All()
 	if err != nil {
 		log.Printf("Error GetGroupsChannels GraphRequest %#v", err)
 		return nil, err
 	}
-	var data GetAllChannelResponse
-	err = json.Unmarshal(body, &data)
-	if err != nil {
-		return nil, err
-	}
-	return data.Value, nil
+	return channels, nil
+}
+
+// IterateGroupsChannels returns an iterator over the channels under a group. Pages are only
+// requested as the iterator advances.
+//
+// Permissions: delegated Channel.ReadBasic.All, application Channel.ReadBasic.All (scopes.GroupsIterateGroupsChannels).
+func (s *ServiceContext) IterateGroupsChannels(ctx context.Context, groupID string) *ChannelIterator {
+	url := fmt.Sprintf("v1.0/teams/%v/channels", groupID)
+	return common.NewIterator[Channel](internal.NewPageIterator(ctx, s.client, url, nil))
 }
 
 // GetAllChannelResponse is the response to expect on a GetGroupsChannels Request.
+//
+// Deprecated: the list methods follow @odata.nextLink themselves; use IterateGroupsChannels to walk the pages.
 type GetAllChannelResponse struct {
 	Context string `json:"@odata.context"`
 	Value   []Channel
@@ -140,22 +228,34 @@ type Channel struct {
 //If successful, this method returns a 200 OK response code and a collection of conversationMember objects in the response body.
 //
 //https://docs.microsoft.com/en-us/graph/api/team-list-members?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated TeamMember.Read.All, application TeamMember.Read.All (scopes.GroupsGetChannelsContact).
 func (s *ServiceContext) GetChannelsContact(groupID string) ([]Contact, error) {
-	url := fmt.Sprintf("v1.0/teams/%v/members", groupID)
-	body, err := internal.GraphRequest(s.client, "GET", url, nil, nil)
+	return s.GetChannelsContactWithContext(context.Background(), groupID)
+}
+
+// GetChannelsContactWithContext is the same as GetChannelsContact, with the request bound to ctx.
+func (s *ServiceContext) GetChannelsContactWithContext(ctx context.Context, groupID string) ([]Contact, error) {
+	contacts, err := s.IterateChannelsContact(ctx, groupID).All()
 	if err != nil {
 		log.Printf("Error GetChannelsContact GraphRequest %#v", err)
 		return nil, err
 	}
-	var data GetAllContactResponse
-	err = json.Unmarshal(body, &data)
-	if err != nil {
-		return nil, err
-	}
-	return data.Value, nil
+	return contacts, nil
+}
+
+// IterateChannelsContact returns an iterator over the members of a team. Pages are only requested
+// as the iterator advances.
+//
+// Permissions: delegated TeamMember.Read.All, application TeamMember.Read.All (scopes.GroupsIterateChannelsContact).
+func (s *ServiceContext) IterateChannelsContact(ctx context.Context, groupID string) *ContactIterator {
+	url := fmt.Sprintf("v1.0/teams/%v/members", groupID)
+	return common.NewIterator[Contact](internal.NewPageIterator(ctx, s.client, url, nil))
 }
 
 // GetAllContactResponse is the response to expect on a GetChannelsContact Request.
+//
+// Deprecated: the list methods follow @odata.nextLink themselves; use IterateChannelsContact to walk the pages.
 type GetAllContactResponse struct {
 	Context string `json:"@odata.context"`
 	Count   int    `json:"@odata.count"`
@@ -232,36 +332,72 @@ type User struct {
 //If successful, this method returns a 200 OK response code and a collection of chatMessage objects in the response body.
 //
 //https://docs.microsoft.com/en-us/graph/api/channel-list-messages?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated ChannelMessage.Read.All, application ChannelMessage.Read.All (scopes.GroupsGetTeamsMessage).
 func (s *ServiceContext) GetTeamsMessage(groupID, channelID string) (GetMessageResponse, error) {
-	var data GetMessageResponse
-	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages", groupID, channelID)
-	body, err := internal.GraphRequest(s.client, "GET", url, nil, nil)
+	return s.GetTeamsMessageWithContext(context.Background(), groupID, channelID)
+}
+
+// GetTeamsMessageWithContext is the same as GetTeamsMessage, with the request bound to ctx.
+func (s *ServiceContext) GetTeamsMessageWithContext(ctx context.Context, groupID, channelID string) (GetMessageResponse, error) {
+	data, err := collectMessages(s.IterateTeamsMessage(ctx, groupID, channelID))
 	if err != nil {
 		log.Printf("Error GetTeamsMessage GraphRequest %#v", err)
 		return data, err
 	}
-	err = json.Unmarshal(body, &data)
-	if err != nil {
-		return data, err
-	}
 	return data, nil
 }
 
+// IterateTeamsMessage returns an iterator over the messages in a channel of a team. Pages are only
+// requested as the iterator advances.
+//
+// Permissions: delegated ChannelMessage.Read.All, application ChannelMessage.Read.All (scopes.GroupsIterateTeamsMessage).
+func (s *ServiceContext) IterateTeamsMessage(ctx context.Context, groupID, channelID string) *ChannelMessageIterator {
+	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages", groupID, channelID)
+	return common.NewIterator[ChannelMessage](internal.NewPageIterator(ctx, s.client, url, nil))
+}
+
 //GetTeamsMessageReplies Get a single reply to a message in a channel of a team.
 //
 //https://docs.microsoft.com/en-us/graph/api/channel-get-messagereply?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated ChannelMessage.Read.All, application ChannelMessage.Read.All (scopes.GroupsGetTeamsMessageReplies).
 func (s *ServiceContext) GetTeamsMessageReplies(groupID, channelID, messageID string) (GetMessageResponse, error) {
-	var data GetMessageResponse
-	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages/%v/replies", groupID, channelID, messageID)
-	body, err := internal.GraphRequest(s.client, "GET", url, nil, nil)
+	return s.GetTeamsMessageRepliesWithContext(context.Background(), groupID, channelID, messageID)
+}
+
+// GetTeamsMessageRepliesWithContext is the same as GetTeamsMessageReplies, with the request bound to ctx.
+func (s *ServiceContext) GetTeamsMessageRepliesWithContext(ctx context.Context, groupID, channelID, messageID string) (GetMessageResponse, error) {
+	data, err := collectMessages(s.IterateTeamsMessageReplies(ctx, groupID, channelID, messageID))
 	if err != nil {
 		log.Printf("Error GetTeamsMessage GraphRequest %#v", err)
 		return data, err
 	}
-	err = json.Unmarshal(body, &data)
+	return data, nil
+}
+
+// IterateTeamsMessageReplies returns an iterator over the replies to a message in a channel of a
+// team. Pages are only requested as the iterator advances.
+//
+// Permissions: delegated ChannelMessage.Read.All, application ChannelMessage.Read.All (scopes.GroupsIterateTeamsMessageReplies).
+func (s *ServiceContext) IterateTeamsMessageReplies(ctx context.Context, groupID, channelID, messageID string) *ChannelMessageIterator {
+	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages/%v/replies", groupID, channelID, messageID)
+	return common.NewIterator[ChannelMessage](internal.NewPageIterator(ctx, s.client, url, nil))
+}
+
+// collectMessages drains it into a GetMessageResponse.
+func collectMessages(it *ChannelMessageIterator) (GetMessageResponse, error) {
+	var data GetMessageResponse
+	var err error
+	data.Value, err = it.All()
 	if err != nil {
 		return data, err
 	}
+	if count, ok := it.Count(); ok {
+		data.Count = int(count)
+	} else {
+		data.Count = len(data.Value)
+	}
 	return data, nil
 }
 
@@ -269,10 +405,17 @@ func (s *ServiceContext) GetTeamsMessageReplies(groupID, channelID, messageID st
 //If successful, this method returns a 200 OK response code and a collection of chatMessage objects in the response body.
 //
 //https://docs.microsoft.com/en-us/graph/api/channel-post-messages?view=graph-rest-beta&tabs=http
+//
+// Permissions: delegated ChannelMessage.Send, application not supported (scopes.GroupsSendTeamsMessage).
 func (s *ServiceContext) SendTeamsMessage(groupID, channelID string, payloadBody interface{}) (ChannelMessage, error) {
+	return s.SendTeamsMessageWithContext(context.Background(), groupID, channelID, payloadBody)
+}
+
+// SendTeamsMessageWithContext is the same as SendTeamsMessage, with the request bound to ctx.
+func (s *ServiceContext) SendTeamsMessageWithContext(ctx context.Context, groupID, channelID string, payloadBody interface{}) (ChannelMessage, error) {
 	var data ChannelMessage
 	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages", groupID, channelID)
-	body, err := internal.GraphRequest(s.client, "POST", url, nil, payloadBody)
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "POST", url, nil, payloadBody)
 	if err != nil {
 		log.Printf("Error SendTeamsMessage GraphRequest %#v", err)
 		return data, err
@@ -288,11 +431,18 @@ func (s *ServiceContext) SendTeamsMessage(groupID, channelID string, payloadBody
 //If successful, this method returns a 200 OK response code and a collection of chatMessage objects in the response body.
 //
 //https://docs.microsoft.com/en-us/graph/api/channel-post-messagereply?view=graph-rest-1.0&tabs=http
+//
+// Permissions: delegated ChannelMessage.Send, application not supported (scopes.GroupsSendTeamsReplyMessage).
 func (s *ServiceContext) SendTeamsReplyMessage(groupID, channelID, replyID string, payloadBody interface{}) (ChannelMessage, error) {
+	return s.SendTeamsReplyMessageWithContext(context.Background(), groupID, channelID, replyID, payloadBody)
+}
+
+// SendTeamsReplyMessageWithContext is the same as SendTeamsReplyMessage, with the request bound to ctx.
+func (s *ServiceContext) SendTeamsReplyMessageWithContext(ctx context.Context, groupID, channelID, replyID string, payloadBody interface{}) (ChannelMessage, error) {
 	var data ChannelMessage
 	//POST /teams/{id}/channels/{id}/messages/{id}/replies
 	url := fmt.Sprintf("beta/teams/%v/channels/%v/messages/%v/replies", groupID, channelID, replyID)
-	body, err := internal.GraphRequest(s.client, "POST", url, nil, payloadBody)
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "POST", url, nil, payloadBody)
 	if err != nil {
 		log.Printf("Error SendTeamsReplyMessage GraphRequest %#v", err)
 		return data, err
@@ -0,0 +1,17 @@
+package groups
+
+import (
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+// GroupIterator lazily walks a list of groups. See common.Iterator.
+type GroupIterator = common.Iterator[Group]
+
+// ChannelIterator lazily walks a list of channels. See common.Iterator.
+type ChannelIterator = common.Iterator[Channel]
+
+// ContactIterator lazily walks a list of team members. See common.Iterator.
+type ContactIterator = common.Iterator[Contact]
+
+// ChannelMessageIterator lazily walks a list of channel messages. See common.Iterator.
+type ChannelMessageIterator = common.Iterator[ChannelMessage]
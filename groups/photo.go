@@ -0,0 +1,97 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+// ListPhotoSizes returns the sizes the profile photo of a group is available in.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-get?view=graph-rest-1.0
+//
+// Permissions: delegated Group.Read.All, application Group.Read.All (scopes.GroupsListPhotoSizes).
+func (s *ServiceContext) ListPhotoSizes(groupID string) ([]common.ProfilePhoto, error) {
+	return s.ListPhotoSizesWithContext(context.Background(), groupID)
+}
+
+// ListPhotoSizesWithContext is the same as ListPhotoSizes, with the request bound to ctx.
+func (s *ServiceContext) ListPhotoSizesWithContext(ctx context.Context, groupID string) ([]common.ProfilePhoto, error) {
+	photos, err := internal.ListPhotos(ctx, s.client, fmt.Sprintf("v1.0/groups/%v", groupID))
+	if err != nil {
+		log.Printf("Error ListPhotoSizes GraphRequest %#v", err)
+	}
+	return photos, err
+}
+
+// GetPhoto returns the metadata of the profile photo of a group in the given size, such as
+// "48x48", or of the largest one available when size is empty.
+//
+// Permissions: delegated Group.Read.All, application Group.Read.All (scopes.GroupsGetPhoto).
+func (s *ServiceContext) GetPhoto(groupID string, size string) (common.ProfilePhoto, error) {
+	return s.GetPhotoWithContext(context.Background(), groupID, size)
+}
+
+// GetPhotoWithContext is the same as GetPhoto, with the request bound to ctx.
+func (s *ServiceContext) GetPhotoWithContext(ctx context.Context, groupID string, size string) (common.ProfilePhoto, error) {
+	photo, err := internal.GetPhoto(ctx, s.client, fmt.Sprintf("v1.0/groups/%v", groupID), size)
+	if err != nil {
+		log.Printf("Error GetPhoto GraphRequest %#v", err)
+	}
+	return photo, err
+}
+
+// DownloadPhoto streams the profile photo of a group in the given size, or the largest one
+// available when size is empty. The caller must close the Body of the photo. If cache holds
+// metadata for the photo, it's only downloaded when its ETag changed, and
+// common.ErrPhotoNotModified is returned otherwise. The cache may be nil.
+//
+// Permissions: delegated Group.Read.All, application Group.Read.All (scopes.GroupsDownloadPhoto).
+func (s *ServiceContext) DownloadPhoto(ctx context.Context, groupID string, size string, cache common.PhotoCache) (*common.Photo, error) {
+	photo, err := internal.DownloadPhoto(ctx, s.client, fmt.Sprintf("v1.0/groups/%v", groupID), size, cache)
+	if err != nil && err != common.ErrPhotoNotModified {
+		log.Printf("Error DownloadPhoto GraphRequest %#v", err)
+	}
+	return photo, err
+}
+
+// UploadPhoto replaces the profile photo of a group. The content type, such as "image/jpeg", is
+// sniffed from data when empty. Photos larger than common.MaxPhotoSize are rejected before being
+// sent.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-update?view=graph-rest-1.0
+//
+// Permissions: delegated Group.ReadWrite.All, application Group.ReadWrite.All (scopes.GroupsUploadPhoto).
+func (s *ServiceContext) UploadPhoto(groupID string, contentType string, data []byte) error {
+	return s.UploadPhotoWithContext(context.Background(), groupID, contentType, data)
+}
+
+// UploadPhotoWithContext is the same as UploadPhoto, with the request bound to ctx.
+func (s *ServiceContext) UploadPhotoWithContext(ctx context.Context, groupID string, contentType string, data []byte) error {
+	err := internal.UploadPhoto(ctx, s.client, fmt.Sprintf("v1.0/groups/%v", groupID), contentType, data)
+	if err != nil {
+		log.Printf("Error UploadPhoto GraphRequest %#v", err)
+	}
+	return err
+}
+
+// DeletePhoto removes the profile photo of a group.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-delete?view=graph-rest-1.0
+//
+// Permissions: delegated Group.ReadWrite.All, application Group.ReadWrite.All (scopes.GroupsDeletePhoto).
+func (s *ServiceContext) DeletePhoto(groupID string) error {
+	return s.DeletePhotoWithContext(context.Background(), groupID)
+}
+
+// DeletePhotoWithContext is the same as DeletePhoto, with the request bound to ctx.
+func (s *ServiceContext) DeletePhotoWithContext(ctx context.Context, groupID string) error {
+	err := internal.DeletePhoto(ctx, s.client, fmt.Sprintf("v1.0/groups/%v", groupID))
+	if err != nil {
+		log.Printf("Error DeletePhoto GraphRequest %#v", err)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+// DeltaIterator walks the pages of a delta query. It starts from the delta link saved in a
+// common.DeltaTokenStore when there is one, and saves the new delta link once the last page has
+// been read. A delta link Graph rejects with 410 Gone is removed from the store, and reported as
+// common.ErrDeltaLinkExpired.
+type DeltaIterator struct {
+	*PageIterator
+	store   common.DeltaTokenStore
+	key     string
+	resumed bool
+	saved   bool
+}
+
+// NewDeltaIterator creates a delta iterator over the delta function at path, such as
+// "v1.0/users/delta". The params are only used when there's no saved delta link to resume from,
+// since a delta link already carries the original query. The store may be nil.
+func NewDeltaIterator(ctx context.Context, c client.Client, path string, params url.Values, store common.DeltaTokenStore, key string) *DeltaIterator {
+	d := &DeltaIterator{store: store, key: key}
+	var link string
+	if store != nil {
+		var err error
+		link, err = store.LoadDeltaLink(ctx, key)
+		if err != nil {
+			d.PageIterator = FailedPageIterator(err)
+			return d
+		}
+	}
+	switch {
+	case link == "":
+		d.PageIterator = NewPageIterator(ctx, c, path, params)
+	case strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://"):
+		d.resumed = true
+		d.PageIterator = NewPageIteratorFromURL(ctx, c, link)
+	default:
+		d.resumed = true
+		d.PageIterator = NewPageIterator(ctx, c, path, url.Values{"$deltatoken": {link}})
+	}
+	return d
+}
+
+// Next decodes the next change into v. Once the changes run out, the new delta link is saved to
+// the store; a failure to save is reported through Err.
+func (d *DeltaIterator) Next(v interface{}) bool {
+	if d.PageIterator.Next(v) {
+		return true
+	}
+	d.finish()
+	return false
+}
+
+// NextPage decodes the rest of the current page of changes, or the whole next page, into v.
+func (d *DeltaIterator) NextPage(v interface{}) bool {
+	if d.PageIterator.NextPage(v) {
+		return true
+	}
+	d.finish()
+	return false
+}
+
+// finish saves the new delta link once the changes ran out, or removes the saved one if Graph
+// rejected it.
+func (d *DeltaIterator) finish() {
+	if gErr, ok := client.AsGraphError(d.err); ok && gErr.StatusCode == http.StatusGone {
+		d.err = common.ErrDeltaLinkExpired
+		if d.store != nil {
+			if err := d.store.SaveDeltaLink(d.ctx, d.key, ""); err != nil {
+				d.err = err
+			}
+		}
+		return
+	}
+	if d.saved || d.store == nil || d.err != nil || d.deltaLink == "" {
+		return
+	}
+	d.saved = true
+	d.err = d.store.SaveDeltaLink(d.ctx, d.key, d.deltaLink)
+}
+
+// Resumed reports whether this query was resumed from a saved delta link.
+func (d *DeltaIterator) Resumed() bool {
+	return d.resumed
+}
+
+// DeltaLink returns the @odata.deltaLink to resume from next time. It's only set once the last page
+// of changes has been read.
+func (d *DeltaIterator) DeltaLink() string {
+	return d.deltaLink
+}
+
+// ChangeType classifies a change, given whether it carried a @removed annotation.
+func (d *DeltaIterator) ChangeType(removed *common.Removed) common.ChangeType {
+	switch {
+	case removed != nil:
+		return common.ChangeRemoved
+	case d.resumed:
+		return common.ChangeUpdated
+	default:
+		return common.ChangeAdded
+	}
+}
@@ -2,73 +2,209 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/cention-mujibur-rahman/msgoraph/client"
 )
 
 const (
-	// GraphAPIRootURL is the root url that the Graph API is hosted on.
-	GraphAPIRootURL = "https://graph.microsoft.com/"
+	// GraphAPIRootURL is the root url that the Graph API is hosted on, unless the client's Options
+	// say otherwise.
+	GraphAPIRootURL = client.DefaultGraphURL
 )
 
 // BasicGraphRequest is similar to GraphRequest, but it assumes an already fully formed url and no
 // body. This is primarily useful for methods that need to pagniate; it just makes that a little bit
 // easier.
 func BasicGraphRequest(client client.Client, method string, url string) ([]byte, error) {
-	req, err := http.NewRequest(method, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	err = client.RefreshCredentials()
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", client.Credentials().AccessToken))
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	return ioutil.ReadAll(resp.Body)
+	return BasicGraphRequestWithContext(context.Background(), client, method, url)
+}
+
+// BasicGraphRequestWithContext is the same as BasicGraphRequest, with the request bound to ctx.
+func BasicGraphRequestWithContext(ctx context.Context, client client.Client, method string, url string) ([]byte, error) {
+	return do(ctx, client, method, url, nil, nil)
 }
 
 // GraphRequest creates and executes a new http request against the Graph API. The path
 // provided should be the entire path of the url, including the version specifier. It returns the
-// response body, along with any errors that might occur during the request process.
+// response body, along with any errors that might occur during the request process. Throttled and
+// transiently failing requests are retried according to the client's RetryPolicy.
 func GraphRequest(client client.Client, method string, path string, params url.Values, body interface{}) ([]byte, error) {
-	var graphURL string
-	if len(params) > 0 {
-		graphURL = fmt.Sprintf("%v%v?%v", GraphAPIRootURL, path, params.Encode())
-	} else {
-		graphURL = fmt.Sprintf("%v%v", GraphAPIRootURL, path)
-	}
-	var bodyBuffered io.Reader
+	return GraphRequestWithContext(context.Background(), client, method, path, params, body)
+}
+
+// GraphRequestWithContext is the same as GraphRequest, with the request bound to ctx. Cancelling
+// ctx stops the request in flight, along with any pending retry.
+func GraphRequestWithContext(ctx context.Context, client client.Client, method string, path string, params url.Values, body interface{}) ([]byte, error) {
+	return GraphRequestWithHeader(ctx, client, method, path, params, nil, body)
+}
+
+// GraphRequestWithHeader is the same as GraphRequestWithContext, with additional headers sent along
+// with the request, such as the ConsistencyLevel header advanced queries need.
+func GraphRequestWithHeader(ctx context.Context, client client.Client, method string, path string, params url.Values, header http.Header, body interface{}) ([]byte, error) {
+	graphURL := GraphURL(client, path, params)
+	var j []byte
 	if body != nil {
-		j, err := json.Marshal(body)
+		var err error
+		j, err = json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		bodyBuffered = bytes.NewBuffer(j)
 	}
-	req, err := http.NewRequest(method, graphURL, bodyBuffered)
+	return do(ctx, client, method, graphURL, header, j)
+}
+
+// GraphRequestReplayable is the same as GraphRequestWithContext, but retries the request whatever
+// its method, for callers which know it to be safe to send twice, such as a $batch call made up of
+// idempotent requests only.
+func GraphRequestReplayable(ctx context.Context, c client.Client, method string, path string, body interface{}) ([]byte, error) {
+	j, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	err = client.RefreshCredentials()
+	var b []byte
+	err = exchange(ctx, c, method, GraphURL(c, path, nil), nil, j, true, func(resp *http.Response) (err error) {
+		b, err = readResponse(resp)
+		return err
+	})
+	return b, err
+}
+
+// GraphURL forms the full url of path on the Graph API root configured for the client. The path
+// should include the version specifier.
+func GraphURL(c client.Client, path string, params url.Values) string {
+	root := client.OptionsFor(c).GraphRoot()
+	if len(params) > 0 {
+		// Encode writes spaces as "+", which not every Graph endpoint reads back as a space inside
+		// of $filter and $search. Literal plus signs are always escaped, so this is safe.
+		return fmt.Sprintf("%v%v?%v", root, path, strings.ReplaceAll(params.Encode(), "+", "%20"))
+	}
+	return fmt.Sprintf("%v%v", root, path)
+}
+
+// do executes the request, retrying it for as long as the client's RetryPolicy allows, and returns
+// the response body.
+func do(ctx context.Context, c client.Client, method string, url string, header http.Header, body []byte) ([]byte, error) {
+	var b []byte
+	replay := CanReplay(client.OptionsFor(c).RetryPolicy(), method)
+	err := exchange(ctx, c, method, url, header, body, replay, func(resp *http.Response) (err error) {
+		b, err = readResponse(resp)
+		return err
+	})
+	return b, err
+}
+
+// stream is the same as do, but returns the response with its body left open for the caller to
+// read and close, so large media doesn't have to be held in memory.
+func stream(ctx context.Context, c client.Client, method string, url string, header http.Header) (*http.Response, error) {
+	var r *http.Response
+	replay := CanReplay(client.OptionsFor(c).RetryPolicy(), method)
+	err := exchange(ctx, c, method, url, header, nil, replay, func(resp *http.Response) error {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			_, err := readResponse(resp)
+			return err
+		}
+		r = resp
+		return nil
+	})
+	return r, err
+}
+
+// exchange sends the request and hands the response to handle, retrying it, when replay is set, for
+// as long as the client's RetryPolicy allows. A request rejected with an invalid_token challenge is
+// sent once more with freshly refreshed credentials, since the token may have been revoked before
+// it expired.
+func exchange(ctx context.Context, c client.Client, method string, url string, header http.Header, body []byte, replay bool, handle func(*http.Response) error) error {
+	opts := client.OptionsFor(c)
+	policy := opts.RetryPolicy()
+	r := NewRetrier(policy)
+	forced := false
+	for {
+		req, token, err := newRequest(ctx, c, method, url, header, body)
+		if err != nil {
+			return err
+		}
+		resp, err := opts.HTTP().Do(req)
+		if err == nil {
+			err = handle(resp)
+		}
+		if err == nil {
+			return nil
+		}
+		if !forced && invalidToken(err) {
+			forced = true
+			c.Credentials().Invalidate(token)
+			continue
+		}
+		if !replay {
+			return err
+		}
+		wait, ok := r.Next(err)
+		if !ok {
+			return err
+		}
+		if err := Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// invalidToken reports whether err is a 401 response challenging the access token as invalid.
+//
+// https://tools.ietf.org/html/rfc6750#section-3.1
+func invalidToken(err error) bool {
+	gerr, ok := client.AsGraphError(err)
+	if !ok || gerr.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	for _, challenge := range gerr.Header.Values("WWW-Authenticate") {
+		if strings.Contains(challenge, "invalid_token") {
+			return true
+		}
+	}
+	return false
+}
+
+// newRequest builds an authenticated request, refreshing the client's credentials if necessary. It
+// returns the access token the request is authenticated with.
+func newRequest(ctx context.Context, c client.Client, method string, url string, header http.Header, body []byte) (*http.Request, string, error) {
+	var bodyBuffered io.Reader
+	if body != nil {
+		bodyBuffered = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyBuffered)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	token, err := client.AccessToken(ctx, c)
+	if err != nil {
+		return nil, "", err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", client.Credentials().AccessToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", token))
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	return req, token, nil
+}
+
+// readResponse drains and closes the response body. Any non-2xx response is turned into a
+// client.GraphError, so callers never end up unmarshalling an error object into a resource.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	return ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, client.NewGraphError(resp.StatusCode, resp.Header, b)
+	}
+	return b, nil
 }
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+)
+
+type staticClient struct {
+	creds *client.RequestCredentials
+	opts  *client.Options
+}
+
+func newStaticClient() *staticClient {
+	return &staticClient{creds: &client.RequestCredentials{
+		AccessToken:          "token",
+		AccessTokenExpiresAt: time.Now().Add(time.Hour),
+	}}
+}
+
+func (c *staticClient) Credentials() *client.RequestCredentials { return c.creds }
+func (c *staticClient) InitializeCredentials() error            { return nil }
+func (c *staticClient) RefreshCredentials() error               { return nil }
+func (c *staticClient) RequestOptions() *client.Options         { return c.opts }
+
+func TestGraphErrorOnNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"Request_ResourceNotFound","message":"Resource 'x' does not exist.","innerError":{"date":"2020-10-15T10:00:00","request-id":"req-1","client-request-id":"client-1"}}}`))
+	}))
+	defer srv.Close()
+	b, err := BasicGraphRequest(newStaticClient(), "GET", srv.URL+"/v1.0/users/x")
+	if b != nil {
+		t.Fatalf("expected no body, got %s", b)
+	}
+	var gErr *client.GraphError
+	if !errors.As(err, &gErr) {
+		t.Fatalf("expected a GraphError, got %v", err)
+	}
+	if gErr.Code != "Request_ResourceNotFound" || gErr.RequestID != "req-1" || gErr.ClientRequestID != "client-1" {
+		t.Fatalf("unexpected error contents: %#v", gErr)
+	}
+	if !client.IsNotFound(err) || client.IsThrottled(err) {
+		t.Fatalf("helpers disagree with status %v", gErr.StatusCode)
+	}
+}
+
+func TestRetryOnThrottling(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	c.opts = &client.Options{Retry: &client.RetryPolicy{MaxAttempts: 3}}
+	if _, err := BasicGraphRequest(c, "GET", srv.URL); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %v", calls)
+	}
+	calls = 0
+	_, err := BasicGraphRequest(c, "POST", srv.URL)
+	if !client.IsThrottled(err) || calls != 1 {
+		t.Fatalf("expected a POST to not be replayed, got %v after %v calls", err, calls)
+	}
+}
+
+func TestCancelledContextStopsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := BasicGraphRequestWithContext(ctx, newStaticClient(), "GET", srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to cut the retry short, got %v", err)
+	}
+}
+
+func TestOptionsRouteRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/me" || r.Header.Get("X-Test") != "middleware" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	c.opts = &client.Options{
+		HTTPClient: srv.Client(),
+		GraphURL:   srv.URL,
+		Middleware: []client.Middleware{
+			func(next http.RoundTripper) http.RoundTripper {
+				return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req.Header.Set("X-Test", "middleware")
+					return next.RoundTrip(req)
+				})
+			},
+		},
+	}
+	if _, err := GraphRequest(c, "GET", "v1.0/me", nil, nil); err != nil {
+		t.Fatalf("expected the request to reach the stub server, got %v", err)
+	}
+}
+
+// refreshingClient hands out a new token every time its credentials are refreshed.
+type refreshingClient struct {
+	staticClient
+	refreshes int
+}
+
+func (c *refreshingClient) RefreshCredentials() error {
+	c.refreshes++
+	c.creds.SetToken(fmt.Sprintf("token-%v", c.refreshes), time.Now().Add(time.Hour))
+	return nil
+}
+
+func TestRetryOnInvalidToken(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if len(tokens) == 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="The token is revoked"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := &refreshingClient{staticClient: *newStaticClient()}
+	if _, err := BasicGraphRequest(c, "POST", srv.URL+"/v1.0/users"); err != nil {
+		t.Fatalf("expected the request to succeed with a refreshed token, got %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "Bearer token" || tokens[1] != "Bearer token-1" {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+)
+
+// page is the envelope every Graph collection response comes wrapped in.
+type page struct {
+	Count     *int64            `json:"@odata.count"`
+	NextLink  string            `json:"@odata.nextLink"`
+	DeltaLink string            `json:"@odata.deltaLink"`
+	Value     []json.RawMessage `json:"value"`
+}
+
+// PageIterator lazily walks a Graph collection, following @odata.nextLink from one page to the
+// next. Nothing is requested until the first call to Next or NextPage, and a page is only requested
+// once the previous one is used up, so a caller can stop at any point by no longer calling them.
+type PageIterator struct {
+	ctx       context.Context
+	client    client.Client
+	nextURL   string
+	header    http.Header
+	items     []json.RawMessage
+	count     *int64
+	deltaLink string
+	err       error
+}
+
+// NewPageIterator creates an iterator over the collection at path. The path should include the
+// version specifier, as in GraphRequest.
+func NewPageIterator(ctx context.Context, c client.Client, path string, params url.Values) *PageIterator {
+	return NewPageIteratorFromURL(ctx, c, GraphURL(c, path, params))
+}
+
+// NewPageIteratorFromURL creates an iterator starting from a fully formed url, such as a
+// @odata.nextLink saved from an earlier walk.
+func NewPageIteratorFromURL(ctx context.Context, c client.Client, url string) *PageIterator {
+	return &PageIterator{ctx: ctx, client: c, nextURL: url}
+}
+
+// WithHeader sets headers to send along with every page request, such as the ConsistencyLevel
+// header advanced queries need.
+func (it *PageIterator) WithHeader(header http.Header) *PageIterator {
+	it.header = header
+	return it
+}
+
+// FailedPageIterator returns an iterator which yields nothing and reports err, for when the
+// arguments to an iterator are invalid.
+func FailedPageIterator(err error) *PageIterator {
+	return &PageIterator{err: err}
+}
+
+// Next decodes the next item of the collection into v, fetching the next page if necessary. It
+// returns false once the collection is exhausted or an error occurred; check Err to tell apart.
+func (it *PageIterator) Next(v interface{}) bool {
+	if !it.fill() {
+		return false
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	if err := json.Unmarshal(item, v); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+// NextPage decodes whatever is left of the current page, or the whole next page, into v, which
+// must be a pointer to a slice. It returns false under the same conditions as Next.
+func (it *PageIterator) NextPage(v interface{}) bool {
+	if !it.fill() {
+		return false
+	}
+	b, err := json.Marshal(it.items)
+	it.items = nil
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+// fill makes sure there's at least one buffered item, requesting pages until there is one or the
+// collection runs out.
+func (it *PageIterator) fill() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || it.nextURL == "" {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		b, err := do(it.ctx, it.client, "GET", it.nextURL, it.header, nil)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		var data page
+		if err := json.Unmarshal(b, &data); err != nil {
+			it.err = err
+			return false
+		}
+		if data.Count != nil {
+			it.count = data.Count
+		}
+		if data.DeltaLink != "" {
+			it.deltaLink = data.DeltaLink
+		}
+		it.items = data.Value
+		it.nextURL = data.NextLink
+	}
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Count returns the @odata.count of the collection, which Graph only includes when $count=true
+// was requested. It's only known once the first page has been fetched.
+func (it *PageIterator) Count() (int64, bool) {
+	if it.count == nil {
+		return 0, false
+	}
+	return *it.count, true
+}
+
+// NextLink returns the url of the page which will be fetched next, or "" if there is none.
+func (it *PageIterator) NextLink() string {
+	return it.nextURL
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+func TestPageIteratorFollowsNextLink(t *testing.T) {
+	var srv *httptest.Server
+	requests := 0
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value":[{"id":"3"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"@odata.count":3,"@odata.nextLink":"%v/v1.0/things?page=2","value":[{"id":"1"},{"id":"2"}]}`, srv.URL)
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	it := NewPageIteratorFromURL(context.Background(), c, srv.URL+"/v1.0/things")
+	var ids []string
+	var item struct {
+		ID string `json:"id"`
+	}
+	for it.Next(&item) {
+		ids = append(ids, item.ID)
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" || requests != 2 {
+		t.Fatalf("unexpected items %v after %v requests", ids, requests)
+	}
+	if count, ok := it.Count(); !ok || count != 3 {
+		t.Fatalf("unexpected count %v", count)
+	}
+
+	requests = 0
+	it = NewPageIteratorFromURL(context.Background(), c, srv.URL+"/v1.0/things")
+	var page []struct{}
+	if !it.NextPage(&page) || len(page) != 2 || requests != 1 {
+		t.Fatalf("expected only the first page to be fetched, got %v items after %v requests", len(page), requests)
+	}
+}
+
+func TestDeltaIteratorSavesAndResumes(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$deltatoken") == "abc" {
+			fmt.Fprintf(w, `{"@odata.deltaLink":"%v/v1.0/users/delta?$deltatoken=def","value":[{"id":"1","@removed":{"reason":"deleted"}}]}`, srv.URL)
+			return
+		}
+		fmt.Fprintf(w, `{"@odata.deltaLink":"%v/v1.0/users/delta?$deltatoken=abc","value":[{"id":"1"}]}`, srv.URL)
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	c.opts = &client.Options{GraphURL: srv.URL}
+	store := common.NewMemoryDeltaTokenStore()
+	var change struct {
+		ID      string          `json:"id"`
+		Removed *common.Removed `json:"@removed"`
+	}
+	for _, want := range []common.ChangeType{common.ChangeAdded, common.ChangeRemoved} {
+		it := NewDeltaIterator(context.Background(), c, "v1.0/users/delta", nil, store, "users")
+		if !it.Next(&change) {
+			t.Fatalf("expected a change, got %v", it.Err())
+		}
+		if got := it.ChangeType(change.Removed); got != want {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		if it.Next(&change) || it.Err() != nil {
+			t.Fatalf("expected a single change, got %v", it.Err())
+		}
+	}
+	link, _ := store.LoadDeltaLink(context.Background(), "users")
+	if link != srv.URL+"/v1.0/users/delta?$deltatoken=def" {
+		t.Fatalf("unexpected saved delta link %q", link)
+	}
+}
+
+func TestDeltaIteratorClearsExpiredLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$deltatoken") == "expired" {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"error":{"code":"syncStateNotFound","message":"The sync state has expired."}}`))
+			return
+		}
+		w.Write([]byte(`{"@odata.deltaLink":"fresh","value":[{"id":"1"}]}`))
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	c.opts = &client.Options{GraphURL: srv.URL}
+	ctx := context.Background()
+	store := common.NewMemoryDeltaTokenStore()
+	store.SaveDeltaLink(ctx, "users", "expired")
+
+	var change struct {
+		ID string `json:"id"`
+	}
+	it := NewDeltaIterator(ctx, c, "v1.0/users/delta", nil, store, "users")
+	if it.Next(&change) || it.Err() != common.ErrDeltaLinkExpired {
+		t.Fatalf("expected ErrDeltaLinkExpired, got %v", it.Err())
+	}
+	if link, _ := store.LoadDeltaLink(ctx, "users"); link != "" {
+		t.Fatalf("expected the expired delta link to be removed, got %q", link)
+	}
+	it = NewDeltaIterator(ctx, c, "v1.0/users/delta", nil, store, "users")
+	if !it.Next(&change) || it.Resumed() || it.ChangeType(nil) != common.ChangeAdded {
+		t.Fatalf("expected a full sync, got %v", it.Err())
+	}
+}
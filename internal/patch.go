@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MarshalPatch marshals the body of a PATCH request, so that only the properties a caller set are
+// changed. v must be a struct, or a pointer to one, whose properties are pointers, slices or maps:
+// nil ones are left out, everything else is sent as is, even if it's empty. A field named
+// NullFields, holding the JSON names of properties to clear, sends those properties as null.
+//
+// Update requests implement json.Marshaler with it:
+//
+//	func (r UpdateThingRequest) MarshalJSON() ([]byte, error) {
+//		return internal.MarshalPatch(r)
+//	}
+func MarshalPatch(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("internal.MarshalPatch: %v is not a struct", rv.Type())
+	}
+	rt := rv.Type()
+
+	nulls := map[string]bool{}
+	if f := rv.FieldByName("NullFields"); f.IsValid() {
+		if f.Kind() != reflect.Slice || f.Type().Elem().Kind() != reflect.String {
+			return nil, fmt.Errorf("internal.MarshalPatch: %v.NullFields is not a list of names", rt)
+		}
+		for i := 0; i < f.Len(); i++ {
+			nulls[f.Index(i).String()] = true
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(name string, value []byte) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := jsonName(field)
+		if field.PkgPath != "" || name == "" {
+			continue
+		}
+		if nulls[name] {
+			if !isUnset(rv.Field(i)) {
+				return nil, fmt.Errorf("internal.MarshalPatch: %v is both set and listed in NullFields", name)
+			}
+			delete(nulls, name)
+			write(name, []byte("null"))
+			continue
+		}
+		if isUnset(rv.Field(i)) {
+			continue
+		}
+		value, err := json.Marshal(rv.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		write(name, value)
+	}
+	buf.WriteByte('}')
+	for name := range nulls {
+		return nil, fmt.Errorf("internal.MarshalPatch: %v has no property %v to clear", rt, name)
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonName returns the JSON name of a struct field, or "" if it isn't marshalled.
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// isUnset reports whether v is a nil pointer, slice, map or interface.
+func isUnset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
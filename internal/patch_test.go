@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testField string
+
+type testUpdate struct {
+	City       *string     `json:"city"`
+	Enabled    *bool       `json:"accountEnabled"`
+	JobTitle   *string     `json:"jobTitle"`
+	Skills     []string    `json:"skills"`
+	NullFields []testField `json:"-"`
+}
+
+func (u testUpdate) MarshalJSON() ([]byte, error) {
+	return MarshalPatch(u)
+}
+
+func TestMarshalPatch(t *testing.T) {
+	title, enabled := "Engineer", false
+	b, err := json.Marshal(testUpdate{JobTitle: &title, Enabled: &enabled, Skills: []string{}, NullFields: []testField{"city"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"city":null,"accountEnabled":false,"jobTitle":"Engineer","skills":[]}` {
+		t.Fatalf("unexpected body %s", b)
+	}
+	if b, _ := json.Marshal(testUpdate{}); string(b) != `{}` {
+		t.Fatalf("expected nothing to be sent, got %s", b)
+	}
+	if _, err := json.Marshal(testUpdate{JobTitle: &title, NullFields: []testField{"jobTitle"}}); err == nil {
+		t.Fatal("expected a field both set and cleared to be rejected")
+	}
+	if _, err := json.Marshal(testUpdate{NullFields: []testField{"title"}}); err == nil {
+		t.Fatal("expected an unknown field to be rejected")
+	}
+}
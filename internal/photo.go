@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+// The photo functions work on the profile photo of the resource at path, such as
+// "v1.0/users/{id}" or "v1.0/groups/{id}". An empty size stands for the default photo.
+
+// ListPhotos returns the sizes the profile photo of the resource at path is available in.
+func ListPhotos(ctx context.Context, c client.Client, path string) ([]common.ProfilePhoto, error) {
+	b, err := GraphRequestWithContext(ctx, c, "GET", path+"/photos", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Value []common.ProfilePhoto `json:"value"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data.Value, nil
+}
+
+// GetPhoto returns the metadata of one size of the profile photo of the resource at path.
+func GetPhoto(ctx context.Context, c client.Client, path string, size string) (common.ProfilePhoto, error) {
+	b, err := GraphRequestWithContext(ctx, c, "GET", photoPath(path, size), nil, nil)
+	if err != nil {
+		return common.ProfilePhoto{}, err
+	}
+	var photo common.ProfilePhoto
+	err = json.Unmarshal(b, &photo)
+	return photo, err
+}
+
+// DownloadPhoto streams one size of the profile photo of the resource at path. When cache holds
+// metadata for the photo, the download is made conditional on its ETag, and
+// common.ErrPhotoNotModified is returned if the photo is still the same. The metadata is saved to
+// the cache once the whole photo has been read, with the ETag the photo was served with, so a
+// photo changed in between isn't mistaken for the one cached. The cache may be nil.
+func DownloadPhoto(ctx context.Context, c client.Client, path string, size string, cache common.PhotoCache) (*common.Photo, error) {
+	key := photoPath(path, size)
+	var header http.Header
+	if cache != nil {
+		cached, err := cache.LoadPhoto(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil && cached.ETag != "" {
+			header = http.Header{"If-None-Match": {cached.ETag}}
+		}
+	}
+	resp, err := stream(ctx, c, "GET", GraphURL(c, key+"/$value", nil), header)
+	if gErr, ok := client.AsGraphError(err); ok && gErr.StatusCode == http.StatusNotModified {
+		return nil, common.ErrPhotoNotModified
+	}
+	if err != nil {
+		return nil, err
+	}
+	photo, err := GetPhoto(ctx, c, path, size)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		photo.ETag = etag
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		photo.ContentType = ct
+	}
+	body := resp.Body
+	if cache != nil {
+		body = &cachingBody{ReadCloser: body, save: func() error {
+			return cache.SavePhoto(ctx, key, &photo)
+		}}
+	}
+	return &common.Photo{ProfilePhoto: photo, Body: body}, nil
+}
+
+// UploadPhoto replaces the profile photo of the resource at path. The content type is sniffed from
+// data when empty, and must be an image either way.
+func UploadPhoto(ctx context.Context, c client.Client, path string, contentType string, data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("photo is empty")
+	}
+	if len(data) > common.MaxPhotoSize {
+		return fmt.Errorf("photo of %v bytes is larger than the %v bytes allowed", len(data), common.MaxPhotoSize)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return fmt.Errorf("photo content type %v is not an image", contentType)
+	}
+	header := http.Header{"Content-Type": {contentType}}
+	_, err := do(ctx, c, "PUT", GraphURL(c, path+"/photo/$value", nil), header, data)
+	return err
+}
+
+// DeletePhoto removes the profile photo of the resource at path.
+func DeletePhoto(ctx context.Context, c client.Client, path string) error {
+	_, err := GraphRequestWithContext(ctx, c, "DELETE", path+"/photo/$value", nil, nil)
+	return err
+}
+
+func photoPath(path string, size string) string {
+	if size == "" {
+		return path + "/photo"
+	}
+	return fmt.Sprintf("%v/photos/%v", path, size)
+}
+
+// cachingBody calls save once the body has been read to the end, so that a photo is only
+// considered cached once the caller has all of it.
+type cachingBody struct {
+	io.ReadCloser
+	save  func() error
+	saved bool
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF && !b.saved {
+		b.saved = true
+		if serr := b.save(); serr != nil {
+			return n, serr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+func TestDownloadPhotoCachesETag(t *testing.T) {
+	etag, next := "v1", "v1"
+	downloads := 0
+	var uploaded []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1.0/users/u/photos/48x48":
+			// The photo may change between the download and the metadata request.
+			etag = next
+			w.Write([]byte(`{"id":"48X48","height":48,"width":48,"@odata.mediaContentType":"image/jpeg","@odata.mediaEtag":"` + etag + `"}`))
+		case "GET /v1.0/users/u/photos/48x48/$value":
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads++
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("jpeg"))
+		case "PUT /v1.0/users/u/photo/$value":
+			if r.Header.Get("Content-Type") != "image/png" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			uploaded, _ = ioutil.ReadAll(r.Body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := newStaticClient()
+	c.opts = &client.Options{HTTPClient: srv.Client(), GraphURL: srv.URL}
+	cache := common.NewMemoryPhotoCache()
+	ctx := context.Background()
+
+	download := func() (string, error) {
+		photo, err := DownloadPhoto(ctx, c, "v1.0/users/u", "48x48", cache)
+		if err != nil {
+			return "", err
+		}
+		defer photo.Body.Close()
+		b, err := ioutil.ReadAll(photo.Body)
+		return string(b), err
+	}
+	if b, err := download(); err != nil || b != "jpeg" {
+		t.Fatalf("unexpected download %q, %v", b, err)
+	}
+	if _, err := download(); err != common.ErrPhotoNotModified {
+		t.Fatalf("expected the cached photo to be current, got %v", err)
+	}
+	etag, next = "v2", "v3"
+	if _, err := download(); err != nil || downloads != 2 {
+		t.Fatalf("expected a changed photo to be downloaded again, got %v after %v downloads", err, downloads)
+	}
+	if cached, _ := cache.LoadPhoto(ctx, "v1.0/users/u/photos/48x48"); cached == nil || cached.ETag != "v2" {
+		t.Fatalf("expected the ETag of the downloaded photo to be cached, got %+v", cached)
+	}
+	if _, err := download(); err != nil || downloads != 3 {
+		t.Fatalf("expected the photo changed since to be downloaded again, got %v after %v downloads", err, downloads)
+	}
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+	if err := UploadPhoto(ctx, c, "v1.0/users/u", "", png); err != nil || !bytes.Equal(uploaded, png) {
+		t.Fatalf("unexpected upload %v, %v", uploaded, err)
+	}
+	if err := UploadPhoto(ctx, c, "v1.0/users/u", "image/png", make([]byte, common.MaxPhotoSize+1)); err == nil {
+		t.Fatalf("expected an oversized photo to be rejected")
+	}
+}
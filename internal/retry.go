@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+)
+
+// Retrier tracks the attempts made for a single request and decides whether, and after how long,
+// the request should be made again.
+type Retrier struct {
+	policy  client.RetryPolicy
+	start   time.Time
+	attempt int
+}
+
+// NewRetrier starts tracking a request which was just attempted for the first time.
+func NewRetrier(policy client.RetryPolicy) *Retrier {
+	return &Retrier{
+		policy:  policy,
+		start:   time.Now(),
+		attempt: 1,
+	}
+}
+
+// CanReplay reports whether the policy allows requests with the given method to be retried at all.
+func CanReplay(policy client.RetryPolicy, method string) bool {
+	return policy.RetryNonIdempotent || (method != http.MethodPost && method != http.MethodPatch)
+}
+
+// Next is called with the error of the attempt which just failed. It returns how long to wait
+// before the next attempt, or false if the request should not be retried. Callers should check
+// CanReplay first.
+func (r *Retrier) Next(err error) (time.Duration, bool) {
+	if r.attempt >= r.policy.MaxAttempts {
+		return 0, false
+	}
+	var wait time.Duration
+	gErr, ok := client.AsGraphError(err)
+	if ok {
+		switch gErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		default:
+			return 0, false
+		}
+		wait, ok = retryAfter(gErr.Header)
+	}
+	if !ok {
+		wait = r.backoff()
+	}
+	if r.policy.MaxElapsed > 0 && time.Since(r.start)+wait > r.policy.MaxElapsed {
+		return 0, false
+	}
+	r.attempt++
+	return wait, true
+}
+
+// backoff returns a jittered exponential delay for the current attempt.
+func (r *Retrier) backoff() time.Duration {
+	shift := r.attempt - 1
+	if shift > 30 {
+		shift = 30
+	}
+	max := r.policy.BaseDelay << uint(shift)
+	if r.policy.MaxDelay > 0 && max > r.policy.MaxDelay {
+		max = r.policy.MaxDelay
+	}
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
+// retryAfter parses the Retry-After header, which Graph sends either as a number of seconds or as
+// an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// Sleep waits for d, returning early with the context's error if ctx is done first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,3 @@
+// Package odata implements a builder for the OData query parameters the Microsoft Graph API
+// accepts: $select, $filter, $orderby, $top, $expand, $search and $count.
+package odata
@@ -0,0 +1,234 @@
+package odata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Property names a property of a Graph resource, or a path to one such as "manager/displayName".
+// users.Field satisfies it.
+type Property interface {
+	PropertyName() string
+}
+
+// Name is a Property given by its plain name.
+type Name string
+
+// PropertyName conforms to the Property interface.
+func (n Name) PropertyName() string {
+	return string(n)
+}
+
+// GUID is a literal of type Edm.Guid, which is written into a filter without quotes.
+type GUID string
+
+// Raw is a literal which is written into a filter exactly as given, for anything the builder
+// doesn't cover such as enum members.
+type Raw string
+
+// Expr is a $filter expression. Build them with the functions in this package, such as Eq, And or
+// Any, and hand the result to Query.Filter.
+type Expr struct {
+	s string
+	// advanced is set when the expression needs the advanced query capabilities of directory
+	// objects, which are only enabled with ConsistencyLevel: eventual.
+	advanced bool
+	// lambdas is how deeply Any and All expressions are nested within the expression.
+	lambdas int
+}
+
+// String returns the expression as it appears in a $filter.
+func (e Expr) String() string {
+	return e.s
+}
+
+// Advanced reports whether the expression uses an operator which is only supported as an advanced
+// query on directory objects, such as ne, not or endsWith.
+func (e Expr) Advanced() bool {
+	return e.advanced
+}
+
+// Literal formats a Go value as an OData literal. Strings are quoted, with embedded quotes doubled;
+// times are written in RFC 3339; GUID and Raw values are written as is.
+func Literal(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.ReplaceAll(t, "'", "''") + "'"
+	case *string:
+		if t == nil {
+			return "null"
+		}
+		return Literal(*t)
+	case GUID:
+		return string(t)
+	case Raw:
+		return string(t)
+	case bool:
+		return strconv.FormatBool(t)
+	case int:
+		return strconv.Itoa(t)
+	case int32:
+		return strconv.FormatInt(int64(t), 10)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return Literal(t.String())
+	}
+	return Literal(fmt.Sprint(v))
+}
+
+func compare(p Property, op string, v interface{}) Expr {
+	return Expr{s: fmt.Sprintf("%v %v %v", p.PropertyName(), op, Literal(v))}
+}
+
+// Eq matches when the property equals v.
+func Eq(p Property, v interface{}) Expr {
+	return compare(p, "eq", v)
+}
+
+// Ne matches when the property doesn't equal v. This is an advanced query on directory objects.
+func Ne(p Property, v interface{}) Expr {
+	e := compare(p, "ne", v)
+	e.advanced = true
+	return e
+}
+
+// Gt matches when the property is greater than v.
+func Gt(p Property, v interface{}) Expr {
+	return compare(p, "gt", v)
+}
+
+// Ge matches when the property is greater than or equal to v.
+func Ge(p Property, v interface{}) Expr {
+	return compare(p, "ge", v)
+}
+
+// Lt matches when the property is less than v.
+func Lt(p Property, v interface{}) Expr {
+	return compare(p, "lt", v)
+}
+
+// Le matches when the property is less than or equal to v.
+func Le(p Property, v interface{}) Expr {
+	return compare(p, "le", v)
+}
+
+// In matches when the property equals any of values.
+func In(p Property, values ...interface{}) Expr {
+	lits := make([]string, len(values))
+	for i, v := range values {
+		lits[i] = Literal(v)
+	}
+	return Expr{s: fmt.Sprintf("%v in (%v)", p.PropertyName(), strings.Join(lits, ","))}
+}
+
+// StartsWith matches when the property starts with prefix.
+func StartsWith(p Property, prefix string) Expr {
+	return Expr{s: fmt.Sprintf("startswith(%v,%v)", p.PropertyName(), Literal(prefix))}
+}
+
+// EndsWith matches when the property ends with suffix. This is an advanced query on directory
+// objects.
+func EndsWith(p Property, suffix string) Expr {
+	return Expr{s: fmt.Sprintf("endswith(%v,%v)", p.PropertyName(), Literal(suffix)), advanced: true}
+}
+
+func join(op string, exprs []Expr) Expr {
+	var nonEmpty []Expr
+	for _, x := range exprs {
+		if x.s != "" {
+			nonEmpty = append(nonEmpty, x)
+		}
+	}
+	if len(nonEmpty) == 1 {
+		return nonEmpty[0]
+	}
+	var e Expr
+	parts := make([]string, len(nonEmpty))
+	for i, x := range nonEmpty {
+		parts[i] = "(" + x.s + ")"
+		e.advanced = e.advanced || x.advanced
+		if x.lambdas > e.lambdas {
+			e.lambdas = x.lambdas
+		}
+	}
+	e.s = strings.Join(parts, " "+op+" ")
+	return e
+}
+
+// And matches when every expression matches.
+func And(exprs ...Expr) Expr {
+	return join("and", exprs)
+}
+
+// Or matches when any of the expressions matches.
+func Or(exprs ...Expr) Expr {
+	return join("or", exprs)
+}
+
+// Not matches when e doesn't. This is an advanced query on directory objects.
+func Not(e Expr) Expr {
+	return Expr{s: "not(" + e.s + ")", advanced: true, lambdas: e.lambdas}
+}
+
+// Lambda is the range variable of an Any or All expression. It is itself a Property, standing for
+// the item of a collection of primitives, and Prop reaches into the item of a collection of
+// complex values.
+type Lambda string
+
+// PropertyName conforms to the Property interface.
+func (l Lambda) PropertyName() string {
+	return string(l)
+}
+
+// Prop returns the named property of the item the range variable stands for.
+func (l Lambda) Prop(name string) Property {
+	return Name(string(l) + "/" + name)
+}
+
+// placeholders numbers the range variables handed to conditions, before they get their final name.
+var placeholders int64
+
+// lambda builds an Any or All expression. Since the range variables of nested lambdas must not
+// shadow each other, cond is handed a placeholder which is named once the depth of the lambdas
+// nested within the body is known: "x" for the innermost, then "x2", "x3" and so on outwards.
+func lambda(op string, collection Property, cond func(item Lambda) Expr) Expr {
+	placeholder := fmt.Sprintf("\x00%v\x00", atomic.AddInt64(&placeholders, 1))
+	body := cond(Lambda(placeholder))
+	depth := body.lambdas + 1
+	name := "x"
+	if depth > 1 {
+		name = fmt.Sprintf("x%v", depth)
+	}
+	s := fmt.Sprintf("%v/%v(%v:%v)", collection.PropertyName(), op, name, body.s)
+	return Expr{
+		s:        strings.ReplaceAll(s, placeholder, name),
+		advanced: body.advanced,
+		lambdas:  depth,
+	}
+}
+
+// Any matches when cond matches at least one item of the collection.
+//
+//	odata.Any(users.FieldAssignedLicenses, func(x odata.Lambda) odata.Expr {
+//		return odata.Eq(x.Prop("skuId"), odata.GUID(skuID))
+//	})
+func Any(collection Property, cond func(item Lambda) Expr) Expr {
+	return lambda("any", collection, cond)
+}
+
+// All matches when cond matches every item of the collection.
+func All(collection Property, cond func(item Lambda) Expr) Expr {
+	return lambda("all", collection, cond)
+}
@@ -0,0 +1,151 @@
+package odata
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query builds the OData query parameters of a Graph request. Every method returns the Query, so
+// calls can be chained; the zero value is an empty query.
+//
+//	q := odata.New().
+//		Select(users.FieldDisplayName, users.FieldMail).
+//		Filter(odata.StartsWith(users.FieldDisplayName, "Jo")).
+//		OrderBy(users.FieldDisplayName).
+//		Top(50)
+//
+// https://docs.microsoft.com/en-us/graph/query-parameters
+type Query struct {
+	selects []string
+	filter  Expr
+	orderBy []string
+	top     int
+	expand  []string
+	search  []string
+	count   bool
+}
+
+// New creates an empty Query.
+func New() *Query {
+	return &Query{}
+}
+
+func names(props []Property) []string {
+	out := make([]string, len(props))
+	for i, p := range props {
+		out[i] = p.PropertyName()
+	}
+	return out
+}
+
+// Select adds properties to $select.
+func (q *Query) Select(props ...Property) *Query {
+	q.selects = append(q.selects, names(props)...)
+	return q
+}
+
+// Filter sets $filter. Calling it again combines the expressions with And.
+func (q *Query) Filter(e Expr) *Query {
+	q.filter = And(q.filter, e)
+	return q
+}
+
+// OrderBy adds properties to $orderby, in ascending order.
+func (q *Query) OrderBy(props ...Property) *Query {
+	q.orderBy = append(q.orderBy, names(props)...)
+	return q
+}
+
+// OrderByDesc adds properties to $orderby, in descending order.
+func (q *Query) OrderByDesc(props ...Property) *Query {
+	for _, n := range names(props) {
+		q.orderBy = append(q.orderBy, n+" desc")
+	}
+	return q
+}
+
+// Top sets $top, the page size the Graph API should return.
+func (q *Query) Top(n int) *Query {
+	q.top = n
+	return q
+}
+
+// Expand adds navigation properties to $expand.
+func (q *Query) Expand(props ...Property) *Query {
+	q.expand = append(q.expand, names(props)...)
+	return q
+}
+
+// Search adds a term to $search, matched against the given property. Several terms are combined
+// with AND. This is an advanced query on directory objects.
+func (q *Query) Search(p Property, term string) *Query {
+	term = strings.ReplaceAll(term, `"`, `\"`)
+	q.search = append(q.search, `"`+p.PropertyName()+":"+term+`"`)
+	return q
+}
+
+// Count sets $count=true, so that the total number of matching items is returned along with the
+// first page. This is an advanced query on directory objects.
+func (q *Query) Count() *Query {
+	q.count = true
+	return q
+}
+
+// Advanced reports whether the query uses the advanced query capabilities of directory objects,
+// which the Graph API only enables when the ConsistencyLevel: eventual header is sent. Header
+// takes care of that.
+//
+// https://docs.microsoft.com/en-us/graph/aad-advanced-queries
+func (q *Query) Advanced() bool {
+	if q == nil {
+		return false
+	}
+	return q.count || len(q.search) > 0 || q.filter.advanced || (q.filter.s != "" && len(q.orderBy) > 0)
+}
+
+// Values renders the query as url parameters.
+func (q *Query) Values() url.Values {
+	v := url.Values{}
+	if q == nil {
+		return v
+	}
+	if len(q.selects) > 0 {
+		v.Set("$select", strings.Join(q.selects, ","))
+	}
+	if q.filter.s != "" {
+		v.Set("$filter", q.filter.s)
+	}
+	if len(q.orderBy) > 0 {
+		v.Set("$orderby", strings.Join(q.orderBy, ","))
+	}
+	if q.top > 0 {
+		v.Set("$top", strconv.Itoa(q.top))
+	}
+	if len(q.expand) > 0 {
+		v.Set("$expand", strings.Join(q.expand, ","))
+	}
+	if len(q.search) > 0 {
+		v.Set("$search", strings.Join(q.search, " AND "))
+	}
+	if q.count {
+		v.Set("$count", "true")
+	}
+	return v
+}
+
+// Header returns the headers the query needs to be sent with; ConsistencyLevel: eventual for
+// advanced queries, and nothing otherwise.
+func (q *Query) Header() http.Header {
+	h := http.Header{}
+	if q.Advanced() {
+		h.Set("ConsistencyLevel", "eventual")
+	}
+	return h
+}
+
+// String renders the query as an encoded query string.
+func (q *Query) String() string {
+	return strings.ReplaceAll(q.Values().Encode(), "+", "%20")
+}
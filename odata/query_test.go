@@ -0,0 +1,53 @@
+package odata
+
+import (
+	"testing"
+)
+
+func TestQueryRendering(t *testing.T) {
+	q := New().
+		Select(Name("id"), Name("displayName")).
+		Filter(Or(
+			StartsWith(Name("displayName"), "O'Brien"),
+			Any(Name("assignedLicenses"), func(x Lambda) Expr {
+				return Eq(x.Prop("skuId"), GUID("184efa21-98c3-4e5d-95ab-d07053a96e67"))
+			}),
+		)).
+		Top(10)
+	v := q.Values()
+	if got := v.Get("$filter"); got != "(startswith(displayName,'O''Brien')) or (assignedLicenses/any(x:x/skuId eq 184efa21-98c3-4e5d-95ab-d07053a96e67))" {
+		t.Fatalf("unexpected $filter %q", got)
+	}
+	if v.Get("$select") != "id,displayName" || v.Get("$top") != "10" {
+		t.Fatalf("unexpected query %v", v)
+	}
+	if q.Header().Get("ConsistencyLevel") != "" {
+		t.Fatalf("a basic query shouldn't ask for eventual consistency")
+	}
+	for _, advanced := range []*Query{
+		New().Count(),
+		New().Search(Name("displayName"), "jo"),
+		New().Filter(Not(Eq(Name("accountEnabled"), true))),
+		New().Filter(EndsWith(Name("mail"), "@contoso.com")),
+		New().Filter(Eq(Name("department"), "Sales")).OrderBy(Name("displayName")),
+	} {
+		if advanced.Header().Get("ConsistencyLevel") != "eventual" {
+			t.Fatalf("expected %v to ask for eventual consistency", advanced)
+		}
+	}
+}
+
+func TestNestedLambdas(t *testing.T) {
+	e := Any(Name("groups"), func(g Lambda) Expr {
+		return And(
+			Eq(g.Prop("kind"), "team"),
+			All(g.Prop("members"), func(m Lambda) Expr {
+				return And(Eq(m.Prop("role"), "owner"), Eq(g.Prop("visibility"), "Public"))
+			}),
+		)
+	})
+	want := "groups/any(x2:(x2/kind eq 'team') and (x2/members/all(x:(x/role eq 'owner') and (x2/visibility eq 'Public'))))"
+	if e.String() != want {
+		t.Fatalf("unexpected filter %q", e)
+	}
+}
@@ -0,0 +1,208 @@
+// Command permgen generates the permission catalog of the scopes package from a snapshot of the
+// permissions published by the Microsoft Graph service principal. The snapshot must be the
+// unedited export of the service principal; permissions which are disabled or only meant for users
+// are left out here, so it never needs trimming by hand. To refresh the snapshot, sign in with the
+// Azure CLI and run make permissions, which does:
+//
+//	az ad sp show --id 00000003-0000-0000-c000-000000000000 > scopes/permissions.json
+//	go generate ./scopes
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// servicePrincipal holds the parts of a service principal which describe its permissions. Any
+// other field of the export is ignored.
+//
+// https://docs.microsoft.com/en-us/graph/api/resources/serviceprincipal
+type servicePrincipal struct {
+	AppID    string `json:"appId"`
+	AppRoles []struct {
+		ID                 string   `json:"id"`
+		Value              string   `json:"value"`
+		DisplayName        string   `json:"displayName"`
+		Description        string   `json:"description"`
+		AllowedMemberTypes []string `json:"allowedMemberTypes"`
+		IsEnabled          bool     `json:"isEnabled"`
+	} `json:"appRoles"`
+	OAuth2PermissionScopes []permissionScope `json:"oauth2PermissionScopes"`
+	// OAuth2Permissions is what versions of the Azure CLI built on the Azure AD Graph API call
+	// OAuth2PermissionScopes.
+	OAuth2Permissions []permissionScope `json:"oauth2Permissions"`
+}
+
+// permissionScope is a delegated permission of a service principal.
+type permissionScope struct {
+	ID                      string `json:"id"`
+	Value                   string `json:"value"`
+	AdminConsentDisplayName string `json:"adminConsentDisplayName"`
+	AdminConsentDescription string `json:"adminConsentDescription"`
+	Type                    string `json:"type"`
+	IsEnabled               bool   `json:"isEnabled"`
+}
+
+// graphAppID is the application id of the Microsoft Graph service principal.
+const graphAppID = "00000003-0000-0000-c000-000000000000"
+
+type permission struct {
+	name                 string
+	adminConsentRequired bool
+	description          string
+	displayString        string
+	id                   string
+	value                string
+	typ                  string
+}
+
+// names keeps the variable names given to some permissions before the catalog was generated.
+var names = map[string]string{
+	"DelegatedOpenid":                   "DelegatedOpenID",
+	"DelegatedDirectoryAccessAsUserAll": "DelegatedDirectoryAccessAsUser",
+}
+
+func main() {
+	in := flag.String("in", "permissions.json", "service principal snapshot to read")
+	out := flag.String("out", "permissions_gen.go", "Go file to write")
+	flag.Parse()
+
+	b, err := ioutil.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var sp servicePrincipal
+	if err := json.Unmarshal(b, &sp); err != nil {
+		log.Fatalf("could not parse %v: %v", *in, err)
+	}
+	if sp.AppID != graphAppID {
+		log.Fatalf("%v is not an export of the Microsoft Graph service principal %v", *in, graphAppID)
+	}
+	if len(sp.OAuth2PermissionScopes) == 0 {
+		sp.OAuth2PermissionScopes = sp.OAuth2Permissions
+	}
+
+	var application, delegated []permission
+	for _, r := range sp.AppRoles {
+		if !r.IsEnabled || !contains(r.AllowedMemberTypes, "Application") {
+			continue
+		}
+		application = append(application, permission{
+			adminConsentRequired: true,
+			description:          r.Description,
+			displayString:        r.DisplayName,
+			id:                   r.ID,
+			value:                r.Value,
+			typ:                  "PermissionTypeApplication",
+		})
+	}
+	for _, s := range sp.OAuth2PermissionScopes {
+		if !s.IsEnabled {
+			continue
+		}
+		delegated = append(delegated, permission{
+			adminConsentRequired: s.Type == "Admin",
+			description:          s.AdminConsentDescription,
+			displayString:        s.AdminConsentDisplayName,
+			id:                   s.ID,
+			value:                s.Value,
+			typ:                  "PermissionTypeDelegated",
+		})
+	}
+	all := append(name(application, "Application"), name(delegated, "Delegated")...)
+	if err := checkDuplicates(all); err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by permgen from %v; DO NOT EDIT.\n\n", *in)
+	fmt.Fprintf(&buf, "package scopes\n\nvar (\n")
+	for _, p := range all {
+		fmt.Fprintf(&buf, "\t// %v %v\n", p.name, p.displayString)
+		fmt.Fprintf(&buf, "\t%v = Scope{\n", p.name)
+		if p.adminConsentRequired {
+			fmt.Fprintf(&buf, "\t\tAdminConsentRequired: true,\n")
+		}
+		fmt.Fprintf(&buf, "\t\tDescription: %q,\n", p.description)
+		fmt.Fprintf(&buf, "\t\tDisplayString: %q,\n", p.displayString)
+		fmt.Fprintf(&buf, "\t\tID: %q,\n", p.id)
+		fmt.Fprintf(&buf, "\t\tPermission: %q,\n", p.value)
+		fmt.Fprintf(&buf, "\t\tType: %v,\n", p.typ)
+		fmt.Fprintf(&buf, "\t}\n")
+	}
+	fmt.Fprintf(&buf, ")\n\n")
+	fmt.Fprintf(&buf, "// catalog lists every permission, application permissions first.\n")
+	fmt.Fprintf(&buf, "var catalog = Scopes{\n")
+	for _, p := range all {
+		fmt.Fprintf(&buf, "\t%v,\n", p.name)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("could not format generated code: %v", err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// name gives each permission its variable name, and sorts them by it.
+func name(perms []permission, prefix string) []permission {
+	for i := range perms {
+		n := prefix + identifier(perms[i].value)
+		if override, ok := names[n]; ok {
+			n = override
+		}
+		perms[i].name = n
+	}
+	sort.Slice(perms, func(i, j int) bool { return perms[i].name < perms[j].name })
+	return perms
+}
+
+// identifier turns a permission value such as "User.ReadBasic.All" or "offline_access" into an
+// exported Go identifier, "UserReadBasicAll" and "OfflineAccess".
+func identifier(value string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '.' || r == '_' || r == '-' }) {
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// checkDuplicates fails on permissions listed twice, which would otherwise generate conflicting
+// variables.
+func checkDuplicates(perms []permission) error {
+	names := map[string]bool{}
+	ids := map[string]bool{}
+	for _, p := range perms {
+		if names[p.name] {
+			return fmt.Errorf("duplicate permission %v", p.name)
+		}
+		names[p.name] = true
+		if ids[p.typ+p.id] {
+			return fmt.Errorf("duplicate permission id %v (%v)", p.id, p.name)
+		}
+		ids[p.typ+p.id] = true
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+package scopes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operation is an operation msgoraph performs against the Graph API, along with the least
+// privileged permissions it can be performed with, as listed in the Graph API reference of the
+// operation. Delegated or Application is left empty when the operation can't be performed with
+// that type of permission, such as reading the signed-in user without one.
+type Operation struct {
+	// Name is the package and method performing the operation, such as "users.CreateUser".
+	Name        string
+	Delegated   Scope
+	Application Scope
+}
+
+// Scope returns the least privileged permission of the given type the operation needs, or false
+// if it can't be performed with that type of permission.
+func (o Operation) Scope(typ PermissionType) (Scope, bool) {
+	scope := o.Delegated
+	if typ == PermissionTypeApplication {
+		scope = o.Application
+	}
+	return scope, scope.Permission != ""
+}
+
+var (
+	// UsersCreateUser is users.ServiceContext.CreateUser.
+	UsersCreateUser = Operation{"users.CreateUser", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersDeleteUser is users.ServiceContext.DeleteUser.
+	UsersDeleteUser = Operation{"users.DeleteUser", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersDeltaUsers is users.ServiceContext.DeltaUsers.
+	UsersDeltaUsers = Operation{"users.DeltaUsers", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersGetLoggedUser is users.ServiceContext.GetLoggedUser.
+	UsersGetLoggedUser = Operation{"users.GetLoggedUser", DelegatedUserRead, Scope{}}
+	// UsersGetUser is users.ServiceContext.GetUser. The default fields include some, such as
+	// jobTitle and mobilePhone, which User.ReadBasic.All doesn't grant.
+	UsersGetUser = Operation{"users.GetUser", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersGetUserWithFields is users.ServiceContext.GetUserWithFields.
+	UsersGetUserWithFields = Operation{"users.GetUserWithFields", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersGetUserWithQuery is users.ServiceContext.GetUserWithQuery.
+	UsersGetUserWithQuery = Operation{"users.GetUserWithQuery", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersIterateUsers is users.ServiceContext.IterateUsers.
+	UsersIterateUsers = Operation{"users.IterateUsers", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersIterateUsersWithQuery is users.ServiceContext.IterateUsersWithQuery.
+	UsersIterateUsersWithQuery = Operation{"users.IterateUsersWithQuery", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersListUsers is users.ServiceContext.ListUsers. The default fields include some, such as
+	// jobTitle and mobilePhone, which User.ReadBasic.All doesn't grant.
+	UsersListUsers = Operation{"users.ListUsers", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersListUsersWithFields is users.ServiceContext.ListUsersWithFields.
+	UsersListUsersWithFields = Operation{"users.ListUsersWithFields", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersListUsersWithQuery is users.ServiceContext.ListUsersWithQuery.
+	UsersListUsersWithQuery = Operation{"users.ListUsersWithQuery", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersUpdateUser is users.ServiceContext.UpdateUser.
+	UsersUpdateUser = Operation{"users.UpdateUser", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersGetManager is users.ServiceContext.GetManager.
+	UsersGetManager = Operation{"users.GetManager", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersSetManager is users.ServiceContext.SetManager.
+	UsersSetManager = Operation{"users.SetManager", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersRemoveManager is users.ServiceContext.RemoveManager.
+	UsersRemoveManager = Operation{"users.RemoveManager", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersListDirectReports is users.ServiceContext.ListDirectReports.
+	UsersListDirectReports = Operation{"users.ListDirectReports", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersIterateDirectReports is users.ServiceContext.IterateDirectReports.
+	UsersIterateDirectReports = Operation{"users.IterateDirectReports", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersManagementChain is users.ServiceContext.ManagementChain.
+	UsersManagementChain = Operation{"users.ManagementChain", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersOrgChart is users.ServiceContext.OrgChart.
+	UsersOrgChart = Operation{"users.OrgChart", DelegatedUserReadAll, ApplicationUserReadAll}
+	// UsersListMemberOf is users.ServiceContext.ListMemberOf.
+	UsersListMemberOf = Operation{"users.ListMemberOf", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersIterateMemberOf is users.ServiceContext.IterateMemberOf.
+	UsersIterateMemberOf = Operation{"users.IterateMemberOf", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersListTransitiveMemberOf is users.ServiceContext.ListTransitiveMemberOf.
+	UsersListTransitiveMemberOf = Operation{"users.ListTransitiveMemberOf", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersIterateTransitiveMemberOf is users.ServiceContext.IterateTransitiveMemberOf.
+	UsersIterateTransitiveMemberOf = Operation{"users.IterateTransitiveMemberOf", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersCheckMemberGroups is users.ServiceContext.CheckMemberGroups.
+	UsersCheckMemberGroups = Operation{"users.CheckMemberGroups", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersGetMemberObjects is users.ServiceContext.GetMemberObjects.
+	UsersGetMemberObjects = Operation{"users.GetMemberObjects", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// UsersListPhotoSizes is users.ServiceContext.ListPhotoSizes.
+	UsersListPhotoSizes = Operation{"users.ListPhotoSizes", DelegatedUserReadBasicAll, ApplicationUserReadAll}
+	// UsersGetPhoto is users.ServiceContext.GetPhoto.
+	UsersGetPhoto = Operation{"users.GetPhoto", DelegatedUserReadBasicAll, ApplicationUserReadAll}
+	// UsersDownloadPhoto is users.ServiceContext.DownloadPhoto.
+	UsersDownloadPhoto = Operation{"users.DownloadPhoto", DelegatedUserReadBasicAll, ApplicationUserReadAll}
+	// UsersUploadPhoto is users.ServiceContext.UploadPhoto.
+	UsersUploadPhoto = Operation{"users.UploadPhoto", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+	// UsersDeletePhoto is users.ServiceContext.DeletePhoto.
+	UsersDeletePhoto = Operation{"users.DeletePhoto", DelegatedUserReadWriteAll, ApplicationUserReadWriteAll}
+
+	// GroupsCreateGroup is groups.ServiceContext.CreateGroup.
+	GroupsCreateGroup = Operation{"groups.CreateGroup", DelegatedGroupReadWriteAll, ApplicationGroupCreate}
+	// GroupsCreateGroupsTeams is groups.ServiceContext.CreateGroupsTeams.
+	GroupsCreateGroupsTeams = Operation{"groups.CreateGroupsTeams", DelegatedGroupReadWriteAll, ApplicationGroupCreate}
+	// GroupsDeltaGroups is groups.ServiceContext.DeltaGroups.
+	GroupsDeltaGroups = Operation{"groups.DeltaGroups", DelegatedGroupReadAll, ApplicationGroupReadAll}
+	// GroupsGetChannelsContact is groups.ServiceContext.GetChannelsContact.
+	GroupsGetChannelsContact = Operation{"groups.GetChannelsContact", DelegatedTeamMemberReadAll, ApplicationTeamMemberReadAll}
+	// GroupsGetGroupsChannels is groups.ServiceContext.GetGroupsChannels.
+	GroupsGetGroupsChannels = Operation{"groups.GetGroupsChannels", DelegatedChannelReadBasicAll, ApplicationChannelReadBasicAll}
+	// GroupsGetGroupsTeams is groups.ServiceContext.GetGroupsTeams.
+	GroupsGetGroupsTeams = Operation{"groups.GetGroupsTeams", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// GroupsGetTeamsMessage is groups.ServiceContext.GetTeamsMessage.
+	GroupsGetTeamsMessage = Operation{"groups.GetTeamsMessage", DelegatedChannelMessageReadAll, ApplicationChannelMessageReadAll}
+	// GroupsGetTeamsMessageReplies is groups.ServiceContext.GetTeamsMessageReplies.
+	GroupsGetTeamsMessageReplies = Operation{"groups.GetTeamsMessageReplies", DelegatedChannelMessageReadAll, ApplicationChannelMessageReadAll}
+	// GroupsIterateChannelsContact is groups.ServiceContext.IterateChannelsContact.
+	GroupsIterateChannelsContact = Operation{"groups.IterateChannelsContact", DelegatedTeamMemberReadAll, ApplicationTeamMemberReadAll}
+	// GroupsIterateGroupsChannels is groups.ServiceContext.IterateGroupsChannels.
+	GroupsIterateGroupsChannels = Operation{"groups.IterateGroupsChannels", DelegatedChannelReadBasicAll, ApplicationChannelReadBasicAll}
+	// GroupsIterateGroupsTeams is groups.ServiceContext.IterateGroupsTeams.
+	GroupsIterateGroupsTeams = Operation{"groups.IterateGroupsTeams", DelegatedGroupMemberReadAll, ApplicationGroupMemberReadAll}
+	// GroupsIterateTeamsMessage is groups.ServiceContext.IterateTeamsMessage.
+	GroupsIterateTeamsMessage = Operation{"groups.IterateTeamsMessage", DelegatedChannelMessageReadAll, ApplicationChannelMessageReadAll}
+	// GroupsIterateTeamsMessageReplies is groups.ServiceContext.IterateTeamsMessageReplies.
+	GroupsIterateTeamsMessageReplies = Operation{"groups.IterateTeamsMessageReplies", DelegatedChannelMessageReadAll, ApplicationChannelMessageReadAll}
+	// GroupsSendTeamsMessage is groups.ServiceContext.SendTeamsMessage.
+	GroupsSendTeamsMessage = Operation{"groups.SendTeamsMessage", DelegatedChannelMessageSend, Scope{}}
+	// GroupsSendTeamsReplyMessage is groups.ServiceContext.SendTeamsReplyMessage.
+	GroupsSendTeamsReplyMessage = Operation{"groups.SendTeamsReplyMessage", DelegatedChannelMessageSend, Scope{}}
+	// GroupsUpdateGroup is groups.ServiceContext.UpdateGroup.
+	GroupsUpdateGroup = Operation{"groups.UpdateGroup", DelegatedGroupReadWriteAll, ApplicationGroupReadWriteAll}
+	// GroupsListPhotoSizes is groups.ServiceContext.ListPhotoSizes.
+	GroupsListPhotoSizes = Operation{"groups.ListPhotoSizes", DelegatedGroupReadAll, ApplicationGroupReadAll}
+	// GroupsGetPhoto is groups.ServiceContext.GetPhoto.
+	GroupsGetPhoto = Operation{"groups.GetPhoto", DelegatedGroupReadAll, ApplicationGroupReadAll}
+	// GroupsDownloadPhoto is groups.ServiceContext.DownloadPhoto.
+	GroupsDownloadPhoto = Operation{"groups.DownloadPhoto", DelegatedGroupReadAll, ApplicationGroupReadAll}
+	// GroupsUploadPhoto is groups.ServiceContext.UploadPhoto.
+	GroupsUploadPhoto = Operation{"groups.UploadPhoto", DelegatedGroupReadWriteAll, ApplicationGroupReadWriteAll}
+	// GroupsDeletePhoto is groups.ServiceContext.DeletePhoto.
+	GroupsDeletePhoto = Operation{"groups.DeletePhoto", DelegatedGroupReadWriteAll, ApplicationGroupReadWriteAll}
+)
+
+// Operations lists every Operation msgoraph performs.
+func Operations() []Operation {
+	return []Operation{
+		UsersCreateUser,
+		UsersDeleteUser,
+		UsersDeltaUsers,
+		UsersGetLoggedUser,
+		UsersGetUser,
+		UsersGetUserWithFields,
+		UsersGetUserWithQuery,
+		UsersIterateUsers,
+		UsersIterateUsersWithQuery,
+		UsersListUsers,
+		UsersListUsersWithFields,
+		UsersListUsersWithQuery,
+		UsersUpdateUser,
+		UsersGetManager,
+		UsersSetManager,
+		UsersRemoveManager,
+		UsersListDirectReports,
+		UsersIterateDirectReports,
+		UsersManagementChain,
+		UsersOrgChart,
+		UsersListMemberOf,
+		UsersIterateMemberOf,
+		UsersListTransitiveMemberOf,
+		UsersIterateTransitiveMemberOf,
+		UsersCheckMemberGroups,
+		UsersGetMemberObjects,
+		UsersListPhotoSizes,
+		UsersGetPhoto,
+		UsersDownloadPhoto,
+		UsersUploadPhoto,
+		UsersDeletePhoto,
+		GroupsCreateGroup,
+		GroupsCreateGroupsTeams,
+		GroupsDeltaGroups,
+		GroupsGetChannelsContact,
+		GroupsGetGroupsChannels,
+		GroupsGetGroupsTeams,
+		GroupsGetTeamsMessage,
+		GroupsGetTeamsMessageReplies,
+		GroupsIterateChannelsContact,
+		GroupsIterateGroupsChannels,
+		GroupsIterateGroupsTeams,
+		GroupsIterateTeamsMessage,
+		GroupsIterateTeamsMessageReplies,
+		GroupsSendTeamsMessage,
+		GroupsSendTeamsReplyMessage,
+		GroupsUpdateGroup,
+		GroupsListPhotoSizes,
+		GroupsGetPhoto,
+		GroupsDownloadPhoto,
+		GroupsUploadPhoto,
+		GroupsDeletePhoto,
+	}
+}
+
+// implies lists, for a permission, the narrower permissions of the same type it grants as well.
+// User.Read and User.ReadWrite also let the user sign in, which the .All permissions don't.
+var implies = map[string][]string{
+	"Directory.ReadWrite.All":   {"Directory.Read.All"},
+	"Group.ReadWrite.All":       {"Group.Read.All", "GroupMember.ReadWrite.All"},
+	"Group.Read.All":            {"GroupMember.Read.All"},
+	"GroupMember.ReadWrite.All": {"GroupMember.Read.All"},
+	"User.ReadWrite.All":        {"User.Read.All"},
+	"User.Read.All":             {"User.ReadBasic.All"},
+	"User.ReadWrite":            {"User.Read"},
+}
+
+// grants reports whether the permission granted includes the permission wanted.
+func grants(granted, wanted string) bool {
+	if strings.EqualFold(granted, wanted) {
+		return true
+	}
+	for _, narrower := range implies[granted] {
+		if grants(narrower, wanted) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeastPrivileged returns the smallest set of permissions of the given type which lets an
+// application perform every one of ops, leaving out permissions already granted by broader ones
+// in the set, along with whether any of them requires admin consent. It fails if one of ops can't
+// be performed with that type of permission.
+// This function will panic if PermissionTypeAll is provided.
+func LeastPrivileged(typ PermissionType, ops ...Operation) (Scopes, bool, error) {
+	if typ == PermissionTypeAll {
+		panic("must provide either PermissionTypeApplication or PermissionTypeDelegated")
+	}
+	var needed Scopes
+	for _, op := range ops {
+		scope, ok := op.Scope(typ)
+		if !ok {
+			return nil, false, fmt.Errorf("%v can't be performed with %v permissions", op.Name, typ)
+		}
+		if !needed.HasScope(scope) {
+			needed = append(needed, scope)
+		}
+	}
+	var minimal Scopes
+	adminConsentRequired := false
+	for i, scope := range needed {
+		redundant := false
+		for j, other := range needed {
+			if i != j && grants(other.Permission, scope.Permission) {
+				redundant = true
+				break
+			}
+		}
+		if redundant {
+			continue
+		}
+		minimal = append(minimal, scope)
+		adminConsentRequired = adminConsentRequired || scope.AdminConsentRequired
+	}
+	sort.Slice(minimal, func(i, j int) bool { return minimal[i].Permission < minimal[j].Permission })
+	return minimal, adminConsentRequired, nil
+}
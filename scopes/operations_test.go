@@ -0,0 +1,74 @@
+package scopes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestLeastPrivileged(t *testing.T) {
+	got, admin, err := LeastPrivileged(PermissionTypeDelegated, UsersGetLoggedUser, UsersListUsers, UsersGetUserWithFields, GroupsSendTeamsMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != DelegatedChannelMessageSend || got[1] != DelegatedUserRead || got[2] != DelegatedUserReadAll || !admin {
+		t.Fatalf("unexpected scopes %v, admin consent %v", got.QueryString(), admin)
+	}
+	got, _, err = LeastPrivileged(PermissionTypeApplication, UsersListUsers, UsersGetUser)
+	if err != nil || len(got) != 1 || got[0] != ApplicationUserReadAll {
+		t.Fatalf("expected the default user fields to need User.Read.All, got %v, %v", got.QueryString(), err)
+	}
+	got, admin, err = LeastPrivileged(PermissionTypeDelegated, UsersGetLoggedUser)
+	if err != nil || len(got) != 1 || got[0] != DelegatedUserRead || admin {
+		t.Fatalf("unexpected scopes %v, admin consent %v, %v", got.QueryString(), admin, err)
+	}
+	if _, _, err := LeastPrivileged(PermissionTypeApplication, UsersGetLoggedUser); err == nil {
+		t.Fatal("expected the signed-in user not to be available to applications")
+	}
+}
+
+// TestOperationsAnnotated checks that every service method of the users and groups packages
+// documents the permissions of its Operation.
+func TestOperationsAnnotated(t *testing.T) {
+	byName := map[string]Operation{}
+	for _, op := range Operations() {
+		byName[op.Name] = op
+	}
+	for _, pkg := range []string{"users", "groups"} {
+		pkgs, err := parser.ParseDir(token.NewFileSet(), "../"+pkg, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range pkgs[pkg].Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || !fn.Name.IsExported() || strings.HasSuffix(fn.Name.Name, "WithContext") {
+					continue
+				}
+				if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); !ok || star.X.(*ast.Ident).Name != "ServiceContext" {
+					continue
+				}
+				name := pkg + "." + fn.Name.Name
+				op, ok := byName[name]
+				if !ok {
+					t.Errorf("%v has no Operation", name)
+					continue
+				}
+				if want := annotation(op); !strings.Contains(fn.Doc.Text(), want) {
+					t.Errorf("expected %v to be documented with %q", name, want)
+				}
+			}
+		}
+	}
+}
+
+func annotation(op Operation) string {
+	application := "application not supported"
+	if scope, ok := op.Scope(PermissionTypeApplication); ok {
+		application = "application " + scope.Permission
+	}
+	return fmt.Sprintf("Permissions: delegated %v, %v", op.Delegated.Permission, application)
+}
@@ -0,0 +1,1276 @@
+// Code generated by permgen from permissions.json; DO NOT EDIT.
+
+package scopes
+
+var (
+	// ApplicationApplicationReadAll Read all applications
+	ApplicationApplicationReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all applications and service principals without a signed-in user.",
+		DisplayString:        "Read all applications",
+		ID:                   "9a5d68dd-52b0-4cc2-bd40-abcf44ac3a30",
+		Permission:           "Application.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationApplicationReadWriteAll Read and write all applications
+	ApplicationApplicationReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update and delete applications and service principals without a signed-in user. Does not allow management of consent grants.",
+		DisplayString:        "Read and write all applications",
+		ID:                   "1bfefb4e-e0b5-418b-a88f-73c46d2cc8e9",
+		Permission:           "Application.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationApplicationReadWriteOwnedBy Manage apps that this app creates or owns
+	ApplicationApplicationReadWriteOwnedBy = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create other applications, and fully manage those applications (read, update, update application secrets and delete), without a signed-in user. It cannot update any apps that it is not an owner of.",
+		DisplayString:        "Manage apps that this app creates or owns",
+		ID:                   "18a4783c-866b-4cc7-a460-3d5e5662c884",
+		Permission:           "Application.ReadWrite.OwnedBy",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationAuditLogReadAll Read all audit log data
+	ApplicationAuditLogReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and query your audit log activities, without a signed-in user.",
+		DisplayString:        "Read all audit log data",
+		ID:                   "b0afded3-3588-46d8-8b3d-9842eff778da",
+		Permission:           "AuditLog.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationCalendarsRead Read calendars in all mailboxes
+	ApplicationCalendarsRead = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read events of all calendars without a signed-in user.",
+		DisplayString:        "Read calendars in all mailboxes",
+		ID:                   "798ee544-9d2d-430c-a058-570e29e34338",
+		Permission:           "Calendars.Read",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationCalendarsReadWrite Read and write calendars in all mailboxes
+	ApplicationCalendarsReadWrite = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update, and delete events of all calendars without a signed-in user.",
+		DisplayString:        "Read and write calendars in all mailboxes",
+		ID:                   "ef54d2bf-783f-4e0f-bca1-3210c0444d99",
+		Permission:           "Calendars.ReadWrite",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationChannelCreate Create channels
+	ApplicationChannelCreate = Scope{
+		AdminConsentRequired: true,
+		Description:          "Create channels in any team, without a signed-in user.",
+		DisplayString:        "Create channels",
+		ID:                   "f3a65bd4-b703-46df-8f7e-0174fea562aa",
+		Permission:           "Channel.Create",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationChannelMessageReadAll Read all channel messages
+	ApplicationChannelMessageReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all channel messages in Microsoft Teams, without a signed-in user.",
+		DisplayString:        "Read all channel messages",
+		ID:                   "7b2449af-6ccd-4f4d-9f78-e550c193f0d1",
+		Permission:           "ChannelMessage.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationChannelReadBasicAll Read the names and descriptions  of all channels
+	ApplicationChannelReadBasicAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Read all channel names and channel descriptions, without a signed-in user.",
+		DisplayString:        "Read the names and descriptions  of all channels",
+		ID:                   "59a6b24b-4225-4393-8165-ebaec5f55d7a",
+		Permission:           "Channel.ReadBasic.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationChatReadAll Read all chat messages
+	ApplicationChatReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all 1-to-1 or group chat messages in Microsoft Teams, without a signed-in user.",
+		DisplayString:        "Read all chat messages",
+		ID:                   "6b7d71aa-70aa-4810-a8d9-5d9fb2830017",
+		Permission:           "Chat.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationContactsRead Read contacts in all mailboxes
+	ApplicationContactsRead = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all contacts in all mailboxes without a signed-in user.",
+		DisplayString:        "Read contacts in all mailboxes",
+		ID:                   "089fe4d0-434a-44c5-8827-41ba8a0b17f5",
+		Permission:           "Contacts.Read",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationContactsReadWrite Read and write contacts in all mailboxes
+	ApplicationContactsReadWrite = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update, and delete all contacts in all mailboxes without a signed-in user.",
+		DisplayString:        "Read and write contacts in all mailboxes",
+		ID:                   "6918b873-d17a-4dc1-b314-35f528134491",
+		Permission:           "Contacts.ReadWrite",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationDeviceReadAll Read all devices
+	ApplicationDeviceReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read your organization's devices' configuration information without a signed-in user.",
+		DisplayString:        "Read all devices",
+		ID:                   "7438b122-aefc-4978-80ed-43db9fcc7715",
+		Permission:           "Device.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationDeviceReadWriteAll Read and write devices
+	ApplicationDeviceReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write all device properties without a signed in user. Does not allow device creation, device deletion, or update of device alternative security identifiers.",
+		DisplayString:        "Read and write devices",
+		ID:                   "1138cb37-bd11-4084-a2b7-9f71582aeddb",
+		Permission:           "Device.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationDirectoryReadAll Read directory data
+	ApplicationDirectoryReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read data in your organization's directory, such as users, groups and apps, without a signed-in user.",
+		DisplayString:        "Read directory data",
+		ID:                   "7ab1d382-f21e-4acd-a863-ba3e13f7da61",
+		Permission:           "Directory.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationDirectoryReadWriteAll Read and write directory data
+	ApplicationDirectoryReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write data in your organization's directory, such as users, and groups, without a signed-in user. Does not allow user or group deletion.",
+		DisplayString:        "Read and write directory data",
+		ID:                   "19dbc75e-c2e2-444c-a770-ec69d8559fc7",
+		Permission:           "Directory.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationFilesReadAll Read files in all site collections
+	ApplicationFilesReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all files in all site collections without a signed in user.",
+		DisplayString:        "Read files in all site collections",
+		ID:                   "01d4889c-1287-42c6-ac1f-5d1e02578ef6",
+		Permission:           "Files.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationFilesReadWriteAll Read and write files in all site collections
+	ApplicationFilesReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read, create, update and delete all files in all site collections without a signed in user.",
+		DisplayString:        "Read and write files in all site collections",
+		ID:                   "75359482-378d-4052-8f01-80520e7db3cd",
+		Permission:           "Files.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationGroupCreate Create groups
+	ApplicationGroupCreate = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create groups without a signed-in user.",
+		DisplayString:        "Create groups",
+		ID:                   "bf7b1a76-6e77-406b-b258-bf5c7720e98f",
+		Permission:           "Group.Create",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationGroupMemberReadAll Read all group memberships
+	ApplicationGroupMemberReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read memberships and basic group properties for all groups without a signed-in user.",
+		DisplayString:        "Read all group memberships",
+		ID:                   "98830695-27a2-44f7-8c18-0c3ebc9698f6",
+		Permission:           "GroupMember.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationGroupMemberReadWriteAll Read and write all group memberships
+	ApplicationGroupMemberReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to list groups, read basic properties, read and update the membership of the groups this app has access to without a signed-in user. Group properties and owners cannot be updated and groups cannot be deleted.",
+		DisplayString:        "Read and write all group memberships",
+		ID:                   "dbaae8cf-10b5-4b86-a4a1-f871c94c6695",
+		Permission:           "GroupMember.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationGroupReadAll Read all groups
+	ApplicationGroupReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read group properties and memberships, and read the calendar and conversations for all groups, without a signed-in user.",
+		DisplayString:        "Read all groups",
+		ID:                   "5b567255-7703-4780-807c-7be8301ae99b",
+		Permission:           "Group.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationGroupReadWriteAll Read and write all groups
+	ApplicationGroupReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create groups, read all group properties and memberships, update group properties and memberships, and delete groups. Also allows the app to read and write group calendar and conversations. All of these operations can be performed by the app without a signed-in user.",
+		DisplayString:        "Read and write all groups",
+		ID:                   "62a82d76-70ea-41e2-9197-370581804d09",
+		Permission:           "Group.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailRead Read mail in all mailboxes
+	ApplicationMailRead = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read mail in all mailboxes without a signed-in user.",
+		DisplayString:        "Read mail in all mailboxes",
+		ID:                   "810c84a8-4a9e-49e6-bf7d-12d183f40d01",
+		Permission:           "Mail.Read",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailReadBasicAll Read basic mail in all mailboxes
+	ApplicationMailReadBasicAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read basic mail properties in all mailboxes without a signed-in user. Includes all properties except body, previewBody, attachments and any extended properties.",
+		DisplayString:        "Read basic mail in all mailboxes",
+		ID:                   "693c5e45-0940-467d-9b8a-1022fb9d42ef",
+		Permission:           "Mail.ReadBasic.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailReadWrite Read and write mail in all mailboxes
+	ApplicationMailReadWrite = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update, and delete mail in all mailboxes without a signed-in user. Does not include permission to send mail.",
+		DisplayString:        "Read and write mail in all mailboxes",
+		ID:                   "e2a3a72e-5f79-4c64-b1b1-878b674786c9",
+		Permission:           "Mail.ReadWrite",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailSend Send mail as any user
+	ApplicationMailSend = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to send mail as any user without a signed-in user.",
+		DisplayString:        "Send mail as any user",
+		ID:                   "b633e1c5-b582-4048-a93e-9f11b44c7e96",
+		Permission:           "Mail.Send",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailboxSettingsRead Read all user mailbox settings
+	ApplicationMailboxSettingsRead = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read user's mailbox settings without a signed-in user. Does not include permission to send mail.",
+		DisplayString:        "Read all user mailbox settings",
+		ID:                   "40f97065-369a-49f4-947c-6a255697ae91",
+		Permission:           "MailboxSettings.Read",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationMailboxSettingsReadWrite Read and write all user mailbox settings
+	ApplicationMailboxSettingsReadWrite = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update, and delete user's mailbox settings without a signed-in user. Does not include permission to send mail.",
+		DisplayString:        "Read and write all user mailbox settings",
+		ID:                   "6931bccd-447a-43d1-b442-00a195474933",
+		Permission:           "MailboxSettings.ReadWrite",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationNotesReadAll Read all OneNote notebooks
+	ApplicationNotesReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all the OneNote notebooks in your organization, without a signed-in user.",
+		DisplayString:        "Read all OneNote notebooks",
+		ID:                   "3aeca27b-ee3a-4c2b-8ded-80376e2134a4",
+		Permission:           "Notes.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationOrganizationReadAll Read organization information
+	ApplicationOrganizationReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the organization and related resources, without a signed-in user. Related resources include things like subscribed skus and tenant branding information.",
+		DisplayString:        "Read organization information",
+		ID:                   "498476ce-e0fe-48b0-b801-37ba7e2685c6",
+		Permission:           "Organization.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationOrganizationReadWriteAll Read and write organization information
+	ApplicationOrganizationReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the organization and related resources, without a signed-in user. Related resources include things like subscribed skus and tenant branding information.",
+		DisplayString:        "Read and write organization information",
+		ID:                   "292d869f-3427-49a8-9dab-8c70152b74e9",
+		Permission:           "Organization.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationPeopleReadAll Read all users' relevant people lists
+	ApplicationPeopleReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read a scored list of people relevant to the signed-in user or other users in the signed-in user's organization. The list can include local contacts, contacts from social networking or your organization's directory, and people from recent communications (such as email and Skype). Also allows the app to search the entire directory of the signed-in user's organization.",
+		DisplayString:        "Read all users' relevant people lists",
+		ID:                   "b528084d-ad10-4598-8b93-929746b4d7d6",
+		Permission:           "People.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationPolicyReadAll Read your organization's policies
+	ApplicationPolicyReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read all your organization's policies without a signed in user.",
+		DisplayString:        "Read your organization's policies",
+		ID:                   "246dd0d5-5bd0-4def-940b-0421030a5b68",
+		Permission:           "Policy.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationPolicyReadWriteConditionalAccess Read and write your organization's conditional access policies
+	ApplicationPolicyReadWriteConditionalAccess = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write your organization's conditional access policies, without a signed-in user.",
+		DisplayString:        "Read and write your organization's conditional access policies",
+		ID:                   "01c0a623-fc9b-48e9-b794-0756f8e8f067",
+		Permission:           "Policy.ReadWrite.ConditionalAccess",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationPresenceReadAll Read presence information for all users
+	ApplicationPresenceReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read presence information of all users in the directory without a signed-in user. Presence information includes activity, availability, status note, calendar out-of-office message, timezone and location.",
+		DisplayString:        "Read presence information for all users",
+		ID:                   "a70e0c2d-e793-494c-94c4-118fa0a67f42",
+		Permission:           "Presence.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationReportsReadAll Read all usage reports
+	ApplicationReportsReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows an app to read all service usage reports without a signed-in user.  Services that provide usage reports include Office 365 and Azure Active Directory.",
+		DisplayString:        "Read all usage reports",
+		ID:                   "230c1aed-a721-4c5d-9cb4-a90514e508ef",
+		Permission:           "Reports.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationRoleManagementReadDirectory Read all directory RBAC settings
+	ApplicationRoleManagementReadDirectory = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the role-based access control (RBAC) settings for your company's directory, without a signed-in user.  This includes reading directory role templates, directory roles and memberships.",
+		DisplayString:        "Read all directory RBAC settings",
+		ID:                   "483bed4a-2ad3-4361-a73b-c83ccdbdc53c",
+		Permission:           "RoleManagement.Read.Directory",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationRoleManagementReadWriteDirectory Read and write all directory RBAC settings
+	ApplicationRoleManagementReadWriteDirectory = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and manage the role-based access control (RBAC) settings for your company's directory, without a signed-in user. This includes instantiating directory roles and managing directory role membership, and reading directory role templates, directory roles and memberships.",
+		DisplayString:        "Read and write all directory RBAC settings",
+		ID:                   "9e3f62cf-ca93-4989-b6ce-bf83c28f9fe8",
+		Permission:           "RoleManagement.ReadWrite.Directory",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationSitesFullControlAll Have full control of all site collections
+	ApplicationSitesFullControlAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to have full control of all site collections without a signed in user.",
+		DisplayString:        "Have full control of all site collections",
+		ID:                   "a82116e5-55eb-4c41-a434-62fe8a61c773",
+		Permission:           "Sites.FullControl.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationSitesManageAll Create, edit, and delete items and lists in all site collections
+	ApplicationSitesManageAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create or delete document libraries and lists in all site collections without a signed in user.",
+		DisplayString:        "Create, edit, and delete items and lists in all site collections",
+		ID:                   "0c0bf378-bf22-4481-8f81-9e89a9b4960a",
+		Permission:           "Sites.Manage.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationSitesReadAll Read items in all site collections
+	ApplicationSitesReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read documents and list items in all site collections without a signed in user.",
+		DisplayString:        "Read items in all site collections",
+		ID:                   "332a536c-c7ef-4017-ab91-336970924f0d",
+		Permission:           "Sites.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationSitesReadWriteAll Read and write items in all site collections
+	ApplicationSitesReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update, and delete documents and list items in all site collections without a signed in user.",
+		DisplayString:        "Read and write items in all site collections",
+		ID:                   "9492366f-7969-46a4-8d15-ed1a20078fff",
+		Permission:           "Sites.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationTeamCreate Create teams
+	ApplicationTeamCreate = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create teams without a signed-in user.",
+		DisplayString:        "Create teams",
+		ID:                   "23fc2474-f741-46ce-8465-674744c5c361",
+		Permission:           "Team.Create",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationTeamMemberReadAll Read the members of all teams
+	ApplicationTeamMemberReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Read the members of all teams, without a signed-in user.",
+		DisplayString:        "Read the members of all teams",
+		ID:                   "660b7406-55f1-41ca-a0ed-0b035e182f3e",
+		Permission:           "TeamMember.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationTeamReadBasicAll Get a list of all teams
+	ApplicationTeamReadBasicAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Get a list of all teams, without a signed-in user.",
+		DisplayString:        "Get a list of all teams",
+		ID:                   "2280dda6-0bfd-44ee-a2f4-cb867cfc4c1e",
+		Permission:           "Team.ReadBasic.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserExportAll Export users' data
+	ApplicationUserExportAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to export organizational users' data, without a signed-in user.",
+		DisplayString:        "Export users' data",
+		ID:                   "405a51b5-8d8d-430b-9842-8be4b0e9f324",
+		Permission:           "User.Export.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserInviteAll Invite guest users to the organization
+	ApplicationUserInviteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to invite guest users to your organization, without a signed-in user.",
+		DisplayString:        "Invite guest users to the organization",
+		ID:                   "09850681-111b-4a89-9bed-3f2cae46d706",
+		Permission:           "User.Invite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserManageIdentitiesAll Manage all users' identities
+	ApplicationUserManageIdentitiesAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read, update and delete identities that are associated with a user's account, without a signed in user. This controls the identities users can sign-in with.",
+		DisplayString:        "Manage all users' identities",
+		ID:                   "c529cfca-c91b-489c-af2b-d92990b66ce6",
+		Permission:           "User.ManageIdentities.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserReadAll Read all users' full profiles
+	ApplicationUserReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the full set of profile properties, group membership, reports and managers of other users in your organization, without a signed-in user.",
+		DisplayString:        "Read all users' full profiles",
+		ID:                   "df021288-bdef-4463-88db-98f22de89214",
+		Permission:           "User.Read.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserReadBasicAll Read all users' basic profiles
+	ApplicationUserReadBasicAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read a basic set of profile properties of other users in your organization without a signed-in user. Includes display name, first and last name, email address, open extensions, and photo.",
+		DisplayString:        "Read all users' basic profiles",
+		ID:                   "97235f07-e226-4f63-ace3-39588e11d3a1",
+		Permission:           "User.ReadBasic.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// ApplicationUserReadWriteAll Read and write all users' full profiles
+	ApplicationUserReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the full set of profile properties, group membership, reports and managers of other users in your organization, without a signed-in user.  Also allows the app to create and delete non-administrative users. Does not allow reset of user passwords.",
+		DisplayString:        "Read and write all users' full profiles",
+		ID:                   "741f803b-c850-494e-b5df-cde7c675a1ca",
+		Permission:           "User.ReadWrite.All",
+		Type:                 PermissionTypeApplication,
+	}
+	// DelegatedApplicationReadAll Read applications
+	DelegatedApplicationReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read applications and service principals on behalf of the signed-in user.",
+		DisplayString:        "Read applications",
+		ID:                   "c79f8feb-a9db-4090-85f9-90d820caa0eb",
+		Permission:           "Application.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedApplicationReadWriteAll Read and write all applications
+	DelegatedApplicationReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create, read, update and delete applications and service principals on behalf of the signed-in user. Does not allow management of consent grants.",
+		DisplayString:        "Read and write all applications",
+		ID:                   "bdfbf15f-ee85-4955-8675-146e8e5296b5",
+		Permission:           "Application.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedAuditLogReadAll Read audit log data
+	DelegatedAuditLogReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and query your audit log activities, on behalf of the signed-in user.",
+		DisplayString:        "Read audit log data",
+		ID:                   "e4c9e354-4dc5-45b8-9e7c-e1393b0b1a20",
+		Permission:           "AuditLog.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedCalendarsRead Read user calendars
+	DelegatedCalendarsRead = Scope{
+		Description:   "Allows the app to read events in user calendars.",
+		DisplayString: "Read user calendars",
+		ID:            "465a38f9-76ea-45b9-9f34-9e8b0d4b0b42",
+		Permission:    "Calendars.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedCalendarsReadShared Read user and shared calendars
+	DelegatedCalendarsReadShared = Scope{
+		Description:   "Allows the app to read events in all calendars that the user can access, including delegate and shared calendars.",
+		DisplayString: "Read user and shared calendars",
+		ID:            "2b9c4092-424d-4249-948d-b43879977640",
+		Permission:    "Calendars.Read.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedCalendarsReadWrite Have full access to user calendars
+	DelegatedCalendarsReadWrite = Scope{
+		Description:   "Allows the app to create, read, update, and delete events in user calendars.",
+		DisplayString: "Have full access to user calendars",
+		ID:            "1ec239c2-d7c9-4623-a91a-a9775856bb36",
+		Permission:    "Calendars.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedCalendarsReadWriteShared Read and write user and shared calendars
+	DelegatedCalendarsReadWriteShared = Scope{
+		Description:   "Allows the app to create, read, update and delete events in all calendars the user has permissions to access. This includes delegate and shared calendars.",
+		DisplayString: "Read and write user and shared calendars",
+		ID:            "12466101-c9b8-439a-8589-dd09ee67e8e9",
+		Permission:    "Calendars.ReadWrite.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedChannelMessageReadAll Read user channel messages
+	DelegatedChannelMessageReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows an app to read a channel's messages in Microsoft Teams, on behalf of the signed-in user.",
+		DisplayString:        "Read user channel messages",
+		ID:                   "767156cb-16ae-4d10-8f8b-41b657c8c8c8",
+		Permission:           "ChannelMessage.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedChannelMessageSend Send channel messages
+	DelegatedChannelMessageSend = Scope{
+		Description:   "Allows an app to send channel messages in Microsoft Teams, on behalf of the signed-in user.",
+		DisplayString: "Send channel messages",
+		ID:            "ebf0f66e-9fb1-49e4-a278-222f76911cf4",
+		Permission:    "ChannelMessage.Send",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedChannelReadBasicAll Read the names and descriptions of channels
+	DelegatedChannelReadBasicAll = Scope{
+		Description:   "Read channel names and channel descriptions, on behalf of the signed-in user.",
+		DisplayString: "Read the names and descriptions of channels",
+		ID:            "9d8982ae-4365-4f57-95e9-d6032a4c0b87",
+		Permission:    "Channel.ReadBasic.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedChatRead Read user chat messages
+	DelegatedChatRead = Scope{
+		Description:   "Allows an app to read 1 on 1 or group chats threads, on behalf of the signed-in user.",
+		DisplayString: "Read user chat messages",
+		ID:            "f501c180-9344-439a-bca0-6cbf209fd270",
+		Permission:    "Chat.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedChatReadWrite Read and write user chat messages
+	DelegatedChatReadWrite = Scope{
+		Description:   "Allows an app to read and write 1 on 1 or group chats threads, on behalf of the signed-in user.",
+		DisplayString: "Read and write user chat messages",
+		ID:            "9ff7295e-131b-4d94-90e1-69fde507ac11",
+		Permission:    "Chat.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedContactsRead Read user contacts
+	DelegatedContactsRead = Scope{
+		Description:   "Allows the app to read user contacts.",
+		DisplayString: "Read user contacts",
+		ID:            "ff74d97f-43af-4b68-9f2a-b77ee6968c5d",
+		Permission:    "Contacts.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedContactsReadShared Read user and shared contacts
+	DelegatedContactsReadShared = Scope{
+		Description:   "Allows the app to read contacts that the user has permissions to access, including the user's own and shared contacts.",
+		DisplayString: "Read user and shared contacts",
+		ID:            "242b9d9e-ed24-4d09-9a52-f43769beb9d4",
+		Permission:    "Contacts.Read.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedContactsReadWrite Have full access to user contacts
+	DelegatedContactsReadWrite = Scope{
+		Description:   "Allows the app to create, read, update, and delete user contacts.",
+		DisplayString: "Have full access to user contacts",
+		ID:            "d56682ec-c09e-4743-aaf4-1a3aac4caa21",
+		Permission:    "Contacts.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedContactsReadWriteShared Read and write user and shared contacts
+	DelegatedContactsReadWriteShared = Scope{
+		Description:   "Allows the app to create, read, update and delete contacts that the user has permissions to, including the user's own and shared contacts.",
+		DisplayString: "Read and write user and shared contacts",
+		ID:            "afb6c84b-06be-49af-80bb-8f3f77004eab",
+		Permission:    "Contacts.ReadWrite.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedDeviceCommand Communicate with user devices
+	DelegatedDeviceCommand = Scope{
+		Description:   "Allows the app to launch another app or communicate with another app on a user's device on behalf of the signed-in user.",
+		DisplayString: "Communicate with user devices",
+		ID:            "bac3b9c2-b516-4ef4-bd3b-c2ef73d8d804",
+		Permission:    "Device.Command",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementAppsReadAll Read Microsoft Intune apps
+	DelegatedDeviceManagementAppsReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the properties, group assignments and status of apps, app configurations and app protection policies managed by Microsoft Intune.",
+		DisplayString:        "Read Microsoft Intune apps",
+		ID:                   "4edf5f54-4666-44af-9de9-0144fb4b6e8c",
+		Permission:           "DeviceManagementApps.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementAppsReadWriteAll Read and write Microsoft Intune apps
+	DelegatedDeviceManagementAppsReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the properties, group assignments and status of apps, app configurations and app protection policies managed by Microsoft Intune.",
+		DisplayString:        "Read and write Microsoft Intune apps",
+		ID:                   "7b3f05d5-f68c-4b8d-8c59-a2ecd12f24af",
+		Permission:           "DeviceManagementApps.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementConfigurationReadAll Read Microsoft Intune device configuration and policies
+	DelegatedDeviceManagementConfigurationReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read properties of Microsoft Intune-managed device configuration and device compliance policies and their assignment to groups.",
+		DisplayString:        "Read Microsoft Intune device configuration and policies",
+		ID:                   "f1493658-876a-4c87-8fa7-edb559b3476a",
+		Permission:           "DeviceManagementConfiguration.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementConfigurationReadWriteAll Read and write Microsoft Intune device configuration and policies
+	DelegatedDeviceManagementConfigurationReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write properties of Microsoft Intune-managed device configuration and device compliance policies and their assignment to groups.",
+		DisplayString:        "Read and write Microsoft Intune device configuration and policies",
+		ID:                   "0883f392-0a7a-443d-8c76-16a6d39c7b63",
+		Permission:           "DeviceManagementConfiguration.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementManagedDevicesPrivilegedOperationsAll Perform user-impacting remote actions on Microsoft Intune devices
+	DelegatedDeviceManagementManagedDevicesPrivilegedOperationsAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to perform remote high impact actions such as wiping the device or resetting the passcode on devices managed by Microsoft Intune.",
+		DisplayString:        "Perform user-impacting remote actions on Microsoft Intune devices",
+		ID:                   "3404d2bf-2b13-457e-a330-c24615765193",
+		Permission:           "DeviceManagementManagedDevices.PrivilegedOperations.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementManagedDevicesReadAll Read Microsoft Intune devices
+	DelegatedDeviceManagementManagedDevicesReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the properties of devices managed by Microsoft Intune.",
+		DisplayString:        "Read Microsoft Intune devices",
+		ID:                   "314874da-47d6-4978-88dc-cf0d37f0bb82",
+		Permission:           "DeviceManagementManagedDevices.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementManagedDevicesReadWriteAll Read and write Microsoft Intune devices
+	DelegatedDeviceManagementManagedDevicesReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the properties of devices managed by Microsoft Intune. Does not allow high impact operations such as remote wipe and password reset on the device’s owner.",
+		DisplayString:        "Read and write Microsoft Intune devices",
+		ID:                   "44642bfe-8385-4adc-8fc6-fe3cb2c375c3",
+		Permission:           "DeviceManagementManagedDevices.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementRBACReadAll Read Microsoft Intune RBAC settings
+	DelegatedDeviceManagementRBACReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the properties relating to the Microsoft Intune Role-Based Access Control (RBAC) settings.",
+		DisplayString:        "Read Microsoft Intune RBAC settings",
+		ID:                   "49f0cc30-024c-4dfd-ab3e-82e137ee5431",
+		Permission:           "DeviceManagementRBAC.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementRBACReadWriteAll Read and write Microsoft Intune RBAC settings
+	DelegatedDeviceManagementRBACReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the properties relating to the Microsoft Intune Role-Based Access Control (RBAC) settings.",
+		DisplayString:        "Read and write Microsoft Intune RBAC settings",
+		ID:                   "0c5e8a55-87a6-4556-93ab-adc52c4d862d",
+		Permission:           "DeviceManagementRBAC.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementServiceConfigReadAll Read Microsoft Intune configuration
+	DelegatedDeviceManagementServiceConfigReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read Intune service properties including device enrollment and third party service connection configuration.",
+		DisplayString:        "Read Microsoft Intune configuration",
+		ID:                   "8696daa5-bce5-4b2e-83f9-51b6defc4e1e",
+		Permission:           "DeviceManagementServiceConfig.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceManagementServiceConfigReadWriteAll Read and write Microsoft Intune configuration
+	DelegatedDeviceManagementServiceConfigReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write Microsoft Intune service properties including device enrollment and third party service connection configuration.",
+		DisplayString:        "Read and write Microsoft Intune configuration",
+		ID:                   "662ed50a-ac44-4eef-ad86-62eed9be2723",
+		Permission:           "DeviceManagementServiceConfig.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDeviceRead Read user devices
+	DelegatedDeviceRead = Scope{
+		Description:   "Allows the app to read a user's list of devices on behalf of the signed-in user.",
+		DisplayString: "Read user devices",
+		ID:            "11d4cd79-5ba5-460f-803f-e22c8ab85ccd",
+		Permission:    "Device.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedDirectoryAccessAsUser Access directory as the signed-in user
+	DelegatedDirectoryAccessAsUser = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to have the same access to information in the directory as the signed-in user.",
+		DisplayString:        "Access directory as the signed-in user",
+		ID:                   "0e263e50-5827-48a4-b97c-d940288653c7",
+		Permission:           "Directory.AccessAsUser.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDirectoryReadAll Read directory data
+	DelegatedDirectoryReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read data in your organization's directory, such as users, groups and apps. Note: Users may consent to applications that require this permission if the application is registered in their own organization’s tenant.",
+		DisplayString:        "Read directory data",
+		ID:                   "06da0dbc-49e2-44d2-8312-53f166ab848a",
+		Permission:           "Directory.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedDirectoryReadWriteAll Read and write directory data
+	DelegatedDirectoryReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write data in your organization's directory, such as users, and groups. It does not allow the app to delete users or groups, or reset user passwords.",
+		DisplayString:        "Read and write directory data",
+		ID:                   "c5366453-9fb0-48a5-a156-24f0c49a4b84",
+		Permission:           "Directory.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedEmail View users' email address
+	DelegatedEmail = Scope{
+		Description:   "Allows the app to read your users' primary email address.",
+		DisplayString: "View users' email address",
+		ID:            "64a6cdd6-aab1-4aaf-94b8-3cc8405e90d0",
+		Permission:    "email",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedFilesRead Read user files
+	DelegatedFilesRead = Scope{
+		Description:   "Allows the app to read the signed-in user's files.",
+		DisplayString: "Read user files",
+		ID:            "10465720-29dd-4523-a11a-6a75c743c9d9",
+		Permission:    "Files.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedFilesReadAll Read all files that user can access
+	DelegatedFilesReadAll = Scope{
+		Description:   "Allows the app to read all files the signed-in user can access.",
+		DisplayString: "Read all files that user can access",
+		ID:            "df85f4d6-205c-4ac5-a5ea-6bf408dba283",
+		Permission:    "Files.Read.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedFilesReadWrite Have full access to user files
+	DelegatedFilesReadWrite = Scope{
+		Description:   "Allows the app to read, create, update and delete the signed-in user's files.",
+		DisplayString: "Have full access to user files",
+		ID:            "5c28f0bf-8a70-41f1-8ab2-9032436ddb65",
+		Permission:    "Files.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedFilesReadWriteAll Have full access to all files user can access
+	DelegatedFilesReadWriteAll = Scope{
+		Description:   "Allows the app to read, create, update and delete all files the signed-in user can access.",
+		DisplayString: "Have full access to all files user can access",
+		ID:            "863451e7-0667-486c-a5d6-d135439485f0",
+		Permission:    "Files.ReadWrite.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedGroupMemberReadAll Read group memberships
+	DelegatedGroupMemberReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to list groups, read basic group properties and read membership of all groups the signed-in user has access to.",
+		DisplayString:        "Read group memberships",
+		ID:                   "bc024368-1153-4739-b217-4326f2e966d0",
+		Permission:           "GroupMember.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedGroupMemberReadWriteAll Read and write group memberships
+	DelegatedGroupMemberReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to list groups, read basic properties, read and update the membership of the groups the signed-in user has access to. Group properties and owners cannot be updated and groups cannot be deleted.",
+		DisplayString:        "Read and write group memberships",
+		ID:                   "f81125ac-d3b7-4573-a3b2-7099cc39df9e",
+		Permission:           "GroupMember.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedGroupReadAll Read all groups
+	DelegatedGroupReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to list groups, and to read their properties and all group memberships on behalf of the signed-in user.  Also allows the app to read calendar, conversations, files, and other group content for all groups the signed-in user can access.",
+		DisplayString:        "Read all groups",
+		ID:                   "5f8c59db-677d-491f-a6b8-5f174b11ec1d",
+		Permission:           "Group.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedGroupReadWriteAll Read and write all groups
+	DelegatedGroupReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to create groups and read all group properties and memberships on behalf of the signed-in user.  Additionally allows group owners to manage their groups and allows group members to update group content.",
+		DisplayString:        "Read and write all groups",
+		ID:                   "4e46008b-f24c-477d-8fff-7bb4ec7aafe0",
+		Permission:           "Group.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedMailRead Read user mail
+	DelegatedMailRead = Scope{
+		Description:   "Allows the app to read the signed-in user's mailbox.",
+		DisplayString: "Read user mail",
+		ID:            "570282fd-fa5c-430d-a7fd-fc8dc98a9dca",
+		Permission:    "Mail.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailReadShared Read user and shared mail
+	DelegatedMailReadShared = Scope{
+		Description:   "Allows the app to read mail a user can access, including their own and shared mail.",
+		DisplayString: "Read user and shared mail",
+		ID:            "7b9103a5-4610-446b-9670-80643382c1fa",
+		Permission:    "Mail.Read.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailReadWrite Read and write access to user mail
+	DelegatedMailReadWrite = Scope{
+		Description:   "Allows the app to create, read, update, and delete email in user mailboxes. Does not include permission to send mail.",
+		DisplayString: "Read and write access to user mail",
+		ID:            "024d486e-b451-40bb-833d-3e66d98c5c73",
+		Permission:    "Mail.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailReadWriteShared Read and write user and shared mail
+	DelegatedMailReadWriteShared = Scope{
+		Description:   "Allows the app to create, read, update, and delete mail a user has permission to access, including their own and shared mail. Does not include permission to send mail.",
+		DisplayString: "Read and write user and shared mail",
+		ID:            "5df07973-7d5d-46ed-9847-1271055cbd51",
+		Permission:    "Mail.ReadWrite.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailSend Send mail as a user
+	DelegatedMailSend = Scope{
+		Description:   "Allows the app to send mail as users in the organization.",
+		DisplayString: "Send mail as a user",
+		ID:            "e383f46e-2787-4529-855e-0e479a3ffac0",
+		Permission:    "Mail.Send",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailSendShared Send mail on behalf of others
+	DelegatedMailSendShared = Scope{
+		Description:   "Allows the app to send mail as the signed-in user, including sending on-behalf of others.",
+		DisplayString: "Send mail on behalf of others",
+		ID:            "a367ab51-6b49-43bf-a716-a1fb06d2a174",
+		Permission:    "Mail.Send.Shared",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailboxSettingsRead Read user mailbox settings
+	DelegatedMailboxSettingsRead = Scope{
+		Description:   "Allows the app to the read user's mailbox settings. Does not include permission to send mail.",
+		DisplayString: "Read user mailbox settings",
+		ID:            "87f447af-9fa4-4c32-9dfa-4a57a73d18ce",
+		Permission:    "MailboxSettings.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedMailboxSettingsReadWrite Read and write user mailbox settings
+	DelegatedMailboxSettingsReadWrite = Scope{
+		Description:   "Allows the app to create, read, update, and delete user's mailbox settings. Does not include permission to send mail.",
+		DisplayString: "Read and write user mailbox settings",
+		ID:            "818c620a-27a9-40bd-a6a5-d96f7d610b4b",
+		Permission:    "MailboxSettings.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedNotesRead Read user OneNote notebooks
+	DelegatedNotesRead = Scope{
+		Description:   "Allows the app to read OneNote notebooks on behalf of the signed-in user.",
+		DisplayString: "Read user OneNote notebooks",
+		ID:            "371361e4-b9e2-4a3f-8315-2a301a3b0a3d",
+		Permission:    "Notes.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedNotesReadWrite Read and write user OneNote notebooks
+	DelegatedNotesReadWrite = Scope{
+		Description:   "Allows the app to read, share, and modify OneNote notebooks on behalf of the signed-in user.",
+		DisplayString: "Read and write user OneNote notebooks",
+		ID:            "615e26af-c38a-4150-ae3e-c3b0d4cb1d6a",
+		Permission:    "Notes.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedOfflineAccess Access user's data anytime
+	DelegatedOfflineAccess = Scope{
+		Description:   "Allows the app to read and update user data, even when they are not currently using the app.",
+		DisplayString: "Access user's data anytime",
+		ID:            "7427e0e9-2fba-42fe-b0c0-848c9e6a8182",
+		Permission:    "offline_access",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedOpenID Sign users in
+	DelegatedOpenID = Scope{
+		Description:   "Allows users to sign in to the app with their work or school accounts and allows the app to see basic user profile information.",
+		DisplayString: "Sign users in",
+		ID:            "37f7f235-527c-4136-accd-4a02d197296e",
+		Permission:    "openid",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedOrganizationReadAll Read organization information
+	DelegatedOrganizationReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the organization and related resources, on behalf of the signed-in user. Related resources include things like subscribed skus and tenant branding information.",
+		DisplayString:        "Read organization information",
+		ID:                   "4908d5b9-3fb2-4b1e-9336-1888b7937185",
+		Permission:           "Organization.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedPeopleRead Read users' relevant people lists
+	DelegatedPeopleRead = Scope{
+		Description:   "Allows the app to read a scored list of people relevant to the signed-in user. The list can include local contacts, contacts from social networking or your organization's directory, and people from recent communications (such as email and Skype).",
+		DisplayString: "Read users' relevant people lists",
+		ID:            "ba47897c-39ec-4d83-8086-ee8256fa737d",
+		Permission:    "People.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedPeopleReadAll Read all users' relevant people lists
+	DelegatedPeopleReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read a scored list of people relevant to the signed-in user or other users in the signed-in user's organization. The list can include local contacts, contacts from social networking or your organization's directory, and people from recent communications (such as email and Skype). Also allows the app to search the entire directory of the signed-in user's organization.",
+		DisplayString:        "Read all users' relevant people lists",
+		ID:                   "b89f9189-71a5-4e70-b041-9887f0bc7e4a",
+		Permission:           "People.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedPolicyReadAll Read your organization's policies
+	DelegatedPolicyReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read your organization's policies on behalf of the signed-in user.",
+		DisplayString:        "Read your organization's policies",
+		ID:                   "572fea84-0151-49b2-9301-11cb16974376",
+		Permission:           "Policy.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedPolicyReadWriteConditionalAccess Read and write your organization's conditional access policies
+	DelegatedPolicyReadWriteConditionalAccess = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write your organization's conditional access policies on behalf of the signed-in user.",
+		DisplayString:        "Read and write your organization's conditional access policies",
+		ID:                   "ad902697-1014-4ef5-81ef-2b4301988e8c",
+		Permission:           "Policy.ReadWrite.ConditionalAccess",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedPresenceRead Read user's presence information
+	DelegatedPresenceRead = Scope{
+		Description:   "Allows the app to read presence information on behalf of the signed-in user. Presence information includes activity, availability, status note, calendar out-of-office message, timezone and location.",
+		DisplayString: "Read user's presence information",
+		ID:            "76bc735e-aecd-4a1d-8b4c-2b915deabb79",
+		Permission:    "Presence.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedProfile View users' basic profile
+	DelegatedProfile = Scope{
+		Description:   "Allows the app to see your users' basic profile (name, picture, user name).",
+		DisplayString: "View users' basic profile",
+		ID:            "14dad69e-099b-42c9-810b-d002981feec1",
+		Permission:    "profile",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedReportsReadAll Read all usage reports
+	DelegatedReportsReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows an app to read all service usage reports on behalf of the signed-in user.  Services that provide usage reports include Office 365 and Azure Active Directory.",
+		DisplayString:        "Read all usage reports",
+		ID:                   "02e97553-ed7b-43d0-ab3c-f8bace0d040c",
+		Permission:           "Reports.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedRoleManagementReadDirectory Read directory RBAC settings
+	DelegatedRoleManagementReadDirectory = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the role-based access control (RBAC) settings for your company's directory, on behalf of the signed-in user.  This includes reading directory role templates, directory roles and memberships.",
+		DisplayString:        "Read directory RBAC settings",
+		ID:                   "741c54c3-0c1e-44a1-818b-3f97ab4e8c83",
+		Permission:           "RoleManagement.Read.Directory",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedSitesFullControlAll Have full control of all site collections
+	DelegatedSitesFullControlAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the application to have full control of all site collections on behalf of the signed-in user.",
+		DisplayString:        "Have full control of all site collections",
+		ID:                   "5a54b8b3-347c-476d-8f8e-42d5c7424d29",
+		Permission:           "Sites.FullControl.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedSitesManageAll Create, edit, and delete items and lists in all site collections
+	DelegatedSitesManageAll = Scope{
+		Description:   "Allows the application to create or delete document libraries and lists in all site collections on behalf of the signed-in user.",
+		DisplayString: "Create, edit, and delete items and lists in all site collections",
+		ID:            "65e50fdc-43b7-4915-933e-e8138f11f40a",
+		Permission:    "Sites.Manage.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedSitesReadAll Read items in all site collections
+	DelegatedSitesReadAll = Scope{
+		Description:   "Allows the application to read documents and list items in all site collections on behalf of the signed-in user.",
+		DisplayString: "Read items in all site collections",
+		ID:            "205e70e5-aba6-4c52-a976-6d2d46c48043",
+		Permission:    "Sites.Read.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedSitesReadWriteAll Edit or delete items in all site collections
+	DelegatedSitesReadWriteAll = Scope{
+		Description:   "Allows the application to edit or delete documents and list items in all site collections on behalf of the signed-in user.",
+		DisplayString: "Edit or delete items in all site collections",
+		ID:            "89fe6a52-be36-487e-b7d8-d061c450a026",
+		Permission:    "Sites.ReadWrite.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedTasksRead Read user's tasks and task lists
+	DelegatedTasksRead = Scope{
+		Description:   "Allows the app to read the signed-in user's tasks and task lists, including any shared with the user.",
+		DisplayString: "Read user's tasks and task lists",
+		ID:            "f45671fb-e0fe-4b4b-be20-3d3ce43f1bcb",
+		Permission:    "Tasks.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedTasksReadWrite Create, read, update, and delete user's tasks and task lists
+	DelegatedTasksReadWrite = Scope{
+		Description:   "Allows the app to create, read, update, and delete the signed-in user's tasks and task lists, including any shared with the user.",
+		DisplayString: "Create, read, update, and delete user's tasks and task lists",
+		ID:            "2219042f-cab5-40cc-b0d2-16b1540b4c5f",
+		Permission:    "Tasks.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedTeamCreate Create teams
+	DelegatedTeamCreate = Scope{
+		Description:   "Allows the app to create teams on behalf of the signed-in user.",
+		DisplayString: "Create teams",
+		ID:            "7825d5d6-6049-4ce7-bdf6-3b8d53f4bcd0",
+		Permission:    "Team.Create",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedTeamMemberReadAll Read the members of teams
+	DelegatedTeamMemberReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Read the members of teams, on behalf of the signed-in user.",
+		DisplayString:        "Read the members of teams",
+		ID:                   "2497278c-d82d-46a2-b1ce-39d4cdde5570",
+		Permission:           "TeamMember.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedTeamReadBasicAll Read the names and descriptions of teams
+	DelegatedTeamReadBasicAll = Scope{
+		Description:   "Read the names and descriptions of teams, on behalf of the signed-in user.",
+		DisplayString: "Read the names and descriptions of teams",
+		ID:            "485be79e-c497-4b35-9400-0e3fa7f2a5d4",
+		Permission:    "Team.ReadBasic.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedUserExportAll Export users' data
+	DelegatedUserExportAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to export an organizational user's data, when performed by a Company Administrator.",
+		DisplayString:        "Export users' data",
+		ID:                   "405a51b5-8d8d-430b-9842-8be4b0e9f324",
+		Permission:           "User.Export.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedUserInviteAll Invite guest users to the organization
+	DelegatedUserInviteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to invite guest users to your organization, on behalf of the signed-in user.",
+		DisplayString:        "Invite guest users to the organization",
+		ID:                   "63dd7cd9-b489-4adf-a28c-ac38b9a0f962",
+		Permission:           "User.Invite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedUserManageIdentitiesAll Manage user identities
+	DelegatedUserManageIdentitiesAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read, update and delete identities that are associated with a user's account that the signed-in user has access to. This controls the identities users can sign-in with.",
+		DisplayString:        "Manage user identities",
+		ID:                   "637d7bec-b31e-4deb-acc9-24275642a2c9",
+		Permission:           "User.ManageIdentities.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedUserRead Sign-in and read user profile
+	DelegatedUserRead = Scope{
+		Description:   "Allows users to sign-in to the app, and allows the app to read the profile of signed-in users. It also allows the app to read basic company information of signed-in users.",
+		DisplayString: "Sign-in and read user profile",
+		ID:            "e1fe6dd8-ba31-4d61-89e7-88639da4683d",
+		Permission:    "User.Read",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedUserReadAll Read all users' full profiles
+	DelegatedUserReadAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read the full set of profile properties, reports, and managers of other users in your organization, on behalf of the signed-in user.",
+		DisplayString:        "Read all users' full profiles",
+		ID:                   "a154be20-db9c-4678-8ab7-66f6cc099a59",
+		Permission:           "User.Read.All",
+		Type:                 PermissionTypeDelegated,
+	}
+	// DelegatedUserReadBasicAll Read all users' basic profiles
+	DelegatedUserReadBasicAll = Scope{
+		Description:   "Allows the app to read a basic set of profile properties of other users in your organization on behalf of the signed-in user. This includes display name, first and last name, email address and photo.",
+		DisplayString: "Read all users' basic profiles",
+		ID:            "b340eb25-3456-403f-be2f-af7a0d370277",
+		Permission:    "User.ReadBasic.All",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedUserReadWrite Read and write access to user profile
+	DelegatedUserReadWrite = Scope{
+		Description:   "Allows the app to read your profile. It also allows the app to update your profile information on your behalf.",
+		DisplayString: "Read and write access to user profile",
+		ID:            "b4e74841-8e56-480b-be8b-910348b18b4c",
+		Permission:    "User.ReadWrite",
+		Type:          PermissionTypeDelegated,
+	}
+	// DelegatedUserReadWriteAll Read and write all users' full profiles
+	DelegatedUserReadWriteAll = Scope{
+		AdminConsentRequired: true,
+		Description:          "Allows the app to read and write the full set of profile properties, reports, and managers of other users in your organization, on behalf of the signed-in user. Also allows the app to create and delete users as well as reset user passwords on behalf of the signed-in user.",
+		DisplayString:        "Read and write all users' full profiles",
+		ID:                   "204e0828-b5ca-4ad8-b9f3-f32a958e7cc4",
+		Permission:           "User.ReadWrite.All",
+		Type:                 PermissionTypeDelegated,
+	}
+)
+
+// catalog lists every permission, application permissions first.
+var catalog = Scopes{
+	ApplicationApplicationReadAll,
+	ApplicationApplicationReadWriteAll,
+	ApplicationApplicationReadWriteOwnedBy,
+	ApplicationAuditLogReadAll,
+	ApplicationCalendarsRead,
+	ApplicationCalendarsReadWrite,
+	ApplicationChannelCreate,
+	ApplicationChannelMessageReadAll,
+	ApplicationChannelReadBasicAll,
+	ApplicationChatReadAll,
+	ApplicationContactsRead,
+	ApplicationContactsReadWrite,
+	ApplicationDeviceReadAll,
+	ApplicationDeviceReadWriteAll,
+	ApplicationDirectoryReadAll,
+	ApplicationDirectoryReadWriteAll,
+	ApplicationFilesReadAll,
+	ApplicationFilesReadWriteAll,
+	ApplicationGroupCreate,
+	ApplicationGroupMemberReadAll,
+	ApplicationGroupMemberReadWriteAll,
+	ApplicationGroupReadAll,
+	ApplicationGroupReadWriteAll,
+	ApplicationMailRead,
+	ApplicationMailReadBasicAll,
+	ApplicationMailReadWrite,
+	ApplicationMailSend,
+	ApplicationMailboxSettingsRead,
+	ApplicationMailboxSettingsReadWrite,
+	ApplicationNotesReadAll,
+	ApplicationOrganizationReadAll,
+	ApplicationOrganizationReadWriteAll,
+	ApplicationPeopleReadAll,
+	ApplicationPolicyReadAll,
+	ApplicationPolicyReadWriteConditionalAccess,
+	ApplicationPresenceReadAll,
+	ApplicationReportsReadAll,
+	ApplicationRoleManagementReadDirectory,
+	ApplicationRoleManagementReadWriteDirectory,
+	ApplicationSitesFullControlAll,
+	ApplicationSitesManageAll,
+	ApplicationSitesReadAll,
+	ApplicationSitesReadWriteAll,
+	ApplicationTeamCreate,
+	ApplicationTeamMemberReadAll,
+	ApplicationTeamReadBasicAll,
+	ApplicationUserExportAll,
+	ApplicationUserInviteAll,
+	ApplicationUserManageIdentitiesAll,
+	ApplicationUserReadAll,
+	ApplicationUserReadBasicAll,
+	ApplicationUserReadWriteAll,
+	DelegatedApplicationReadAll,
+	DelegatedApplicationReadWriteAll,
+	DelegatedAuditLogReadAll,
+	DelegatedCalendarsRead,
+	DelegatedCalendarsReadShared,
+	DelegatedCalendarsReadWrite,
+	DelegatedCalendarsReadWriteShared,
+	DelegatedChannelMessageReadAll,
+	DelegatedChannelMessageSend,
+	DelegatedChannelReadBasicAll,
+	DelegatedChatRead,
+	DelegatedChatReadWrite,
+	DelegatedContactsRead,
+	DelegatedContactsReadShared,
+	DelegatedContactsReadWrite,
+	DelegatedContactsReadWriteShared,
+	DelegatedDeviceCommand,
+	DelegatedDeviceManagementAppsReadAll,
+	DelegatedDeviceManagementAppsReadWriteAll,
+	DelegatedDeviceManagementConfigurationReadAll,
+	DelegatedDeviceManagementConfigurationReadWriteAll,
+	DelegatedDeviceManagementManagedDevicesPrivilegedOperationsAll,
+	DelegatedDeviceManagementManagedDevicesReadAll,
+	DelegatedDeviceManagementManagedDevicesReadWriteAll,
+	DelegatedDeviceManagementRBACReadAll,
+	DelegatedDeviceManagementRBACReadWriteAll,
+	DelegatedDeviceManagementServiceConfigReadAll,
+	DelegatedDeviceManagementServiceConfigReadWriteAll,
+	DelegatedDeviceRead,
+	DelegatedDirectoryAccessAsUser,
+	DelegatedDirectoryReadAll,
+	DelegatedDirectoryReadWriteAll,
+	DelegatedEmail,
+	DelegatedFilesRead,
+	DelegatedFilesReadAll,
+	DelegatedFilesReadWrite,
+	DelegatedFilesReadWriteAll,
+	DelegatedGroupMemberReadAll,
+	DelegatedGroupMemberReadWriteAll,
+	DelegatedGroupReadAll,
+	DelegatedGroupReadWriteAll,
+	DelegatedMailRead,
+	DelegatedMailReadShared,
+	DelegatedMailReadWrite,
+	DelegatedMailReadWriteShared,
+	DelegatedMailSend,
+	DelegatedMailSendShared,
+	DelegatedMailboxSettingsRead,
+	DelegatedMailboxSettingsReadWrite,
+	DelegatedNotesRead,
+	DelegatedNotesReadWrite,
+	DelegatedOfflineAccess,
+	DelegatedOpenID,
+	DelegatedOrganizationReadAll,
+	DelegatedPeopleRead,
+	DelegatedPeopleReadAll,
+	DelegatedPolicyReadAll,
+	DelegatedPolicyReadWriteConditionalAccess,
+	DelegatedPresenceRead,
+	DelegatedProfile,
+	DelegatedReportsReadAll,
+	DelegatedRoleManagementReadDirectory,
+	DelegatedSitesFullControlAll,
+	DelegatedSitesManageAll,
+	DelegatedSitesReadAll,
+	DelegatedSitesReadWriteAll,
+	DelegatedTasksRead,
+	DelegatedTasksReadWrite,
+	DelegatedTeamCreate,
+	DelegatedTeamMemberReadAll,
+	DelegatedTeamReadBasicAll,
+	DelegatedUserExportAll,
+	DelegatedUserInviteAll,
+	DelegatedUserManageIdentitiesAll,
+	DelegatedUserRead,
+	DelegatedUserReadAll,
+	DelegatedUserReadBasicAll,
+	DelegatedUserReadWrite,
+	DelegatedUserReadWriteAll,
+}
@@ -7,16 +7,20 @@ import (
 // PermissionType defines whether a permission is delegated or application.
 type PermissionType string
 
+//go:generate go run ./internal/permgen -in permissions.json -out permissions_gen.go
+
 // Scope contains information about the OAuth scopes exported by the Graph API. This includes the
 // permission itself, as well as information about displaying it in a UI if you ever need to. This
-// information is all pulled from the Graph API documentation at the time of this file's commit,
-// and could change at any point (https://developer.microsoft.com/en-us/graph/docs/concepts/permissions_reference).
+// information is generated from permissions.json, a snapshot of the permissions published by the
+// Microsoft Graph service principal, and could change at any point (https://developer.microsoft.com/en-us/graph/docs/concepts/permissions_reference).
 type Scope struct {
 	AdminConsentRequired bool
 	DisplayString        string
 	Description          string
-	Permission           string
-	Type                 PermissionType
+	// ID is the permission's id, which application manifests refer to it by.
+	ID         string
+	Permission string
+	Type       PermissionType
 }
 
 // Scopes is an alias to an array of scopes, with some additional logic on the type to make interfacing
@@ -33,66 +37,14 @@ const (
 	PermissionTypeDelegated = "delegated"
 )
 
-// All returns a list of every permission in the graph api by permission type.
+// All returns a list of every permission in the graph api by permission type. Application
+// permissions are listed first.
 func All(typ PermissionType) Scopes {
 	var scopes Scopes
-	if typ == PermissionTypeAll || typ == PermissionTypeApplication {
-		scopes = append(scopes, []Scope{
-			ApplicationContactsRead,
-			ApplicationContactsReadWrite,
-			ApplicationContactsRead,
-			ApplicationContactsReadWrite,
-			ApplicationDeviceReadWriteAll,
-			ApplicationDirectoryReadAll,
-			ApplicationDirectoryReadWriteAll,
-			ApplicationPeopleReadAll,
-			ApplicationUserReadAll,
-			ApplicationUserReadWriteAll,
-			ApplicationUserInviteAll,
-			ApplicationUserExportAll,
-		}...)
-	}
-	if typ == PermissionTypeAll || typ == PermissionTypeDelegated {
-		scopes = append(scopes, []Scope{
-			DelegatedCalendarsRead,
-			DelegatedCalendarsReadShared,
-			DelegatedCalendarsReadWrite,
-			DelegatedCalendarsReadWriteShared,
-			DelegatedContactsRead,
-			DelegatedCalendarsRead,
-			DelegatedCalendarsReadShared,
-			DelegatedCalendarsReadWrite,
-			DelegatedCalendarsReadWriteShared,
-			DelegatedDeviceRead,
-			DelegatedDeviceCommand,
-			DelegatedDirectoryReadAll,
-			DelegatedDirectoryReadWriteAll,
-			DelegatedDirectoryAccessAsUser,
-			DelegatedDeviceManagementAppsReadAll,
-			DelegatedDeviceManagementAppsReadWriteAll,
-			DelegatedDeviceManagementConfigurationReadAll,
-			DelegatedDeviceManagementConfigurationReadWriteAll,
-			DelegatedDeviceManagementManagedDevicesPrivilegedOperationsAll,
-			DelegatedDeviceManagementManagedDevicesReadAll,
-			DelegatedDeviceManagementManagedDevicesReadWriteAll,
-			DelegatedDeviceManagementRBACReadAll,
-			DelegatedDeviceManagementRBACReadWriteAll,
-			DelegatedDeviceManagementServiceConfigReadAll,
-			DelegatedDeviceManagementServiceConfigReadWriteAll,
-			DelegatedEmail,
-			DelegatedOfflineAccess,
-			DelegatedOpenID,
-			DelegatedProfile,
-			DelegatedPeopleRead,
-			DelegatedPeopleReadAll,
-			DelegatedUserRead,
-			DelegatedUserReadWrite,
-			DelegatedUserReadBasicAll,
-			DelegatedUserReadAll,
-			DelegatedUserReadWriteAll,
-			DelegatedUserInviteAll,
-			DelegatedUserExportAll,
-		}...)
+	for _, scope := range catalog {
+		if typ == PermissionTypeAll || typ == scope.Type {
+			scopes = append(scopes, scope)
+		}
 	}
 	return scopes
 }
@@ -0,0 +1,87 @@
+package scopes
+
+import (
+	"strings"
+	"testing"
+)
+
+// The permission ids below are fixed by Microsoft Graph and never change, so they catch a
+// generator or snapshot which mixes them up.
+var knownPermissions = []struct {
+	typ                  PermissionType
+	permission           string
+	id                   string
+	adminConsentRequired bool
+}{
+	{PermissionTypeApplication, "Application.Read.All", "9a5d68dd-52b0-4cc2-bd40-abcf44ac3a30", true},
+	{PermissionTypeApplication, "Directory.Read.All", "7ab1d382-f21e-4acd-a863-ba3e13f7da61", true},
+	{PermissionTypeApplication, "Group.Read.All", "5b567255-7703-4780-807c-7be8301ae99b", true},
+	{PermissionTypeApplication, "GroupMember.Read.All", "98830695-27a2-44f7-8c18-0c3ebc9698f6", true},
+	{PermissionTypeApplication, "Mail.Send", "b633e1c5-b582-4048-a93e-9f11b44c7e96", true},
+	{PermissionTypeApplication, "User.Read.All", "df021288-bdef-4463-88db-98f22de89214", true},
+	{PermissionTypeApplication, "User.ReadWrite.All", "741f803b-c850-494e-b5df-cde7c675a1ca", true},
+	{PermissionTypeDelegated, "Directory.Read.All", "06da0dbc-49e2-44d2-8312-53f166ab848a", true},
+	{PermissionTypeDelegated, "email", "64a6cdd6-aab1-4aaf-94b8-3cc8405e90d0", false},
+	{PermissionTypeDelegated, "Group.Read.All", "5f8c59db-677d-491f-a6b8-5f174b11ec1d", true},
+	{PermissionTypeDelegated, "Mail.Send", "e383f46e-2787-4529-855e-0e479a3ffac0", false},
+	{PermissionTypeDelegated, "offline_access", "7427e0e9-2fba-42fe-b0c0-848c9e6a8182", false},
+	{PermissionTypeDelegated, "openid", "37f7f235-527c-4136-accd-4a02d197296e", false},
+	{PermissionTypeDelegated, "profile", "14dad69e-099b-42c9-810b-d002981feec1", false},
+	{PermissionTypeDelegated, "User.Read", "e1fe6dd8-ba31-4d61-89e7-88639da4683d", false},
+	{PermissionTypeDelegated, "User.Read.All", "a154be20-db9c-4678-8ab7-66f6cc099a59", true},
+	{PermissionTypeDelegated, "User.ReadBasic.All", "b340eb25-3456-403f-be2f-af7a0d370277", false},
+}
+
+// The number of permissions in permissions.json. Update them when refreshing the snapshot, once
+// the difference has been checked against the Graph changelog.
+const (
+	applicationPermissions = 52
+	delegatedPermissions   = 79
+)
+
+func TestCatalogKnownPermissions(t *testing.T) {
+	for _, k := range knownPermissions {
+		got := All(k.typ).Find(k.permission)
+		if got == nil {
+			t.Errorf("%v %v is missing from the catalog", k.typ, k.permission)
+			continue
+		}
+		if got.ID != k.id || got.AdminConsentRequired != k.adminConsentRequired || got.Type != k.typ {
+			t.Errorf("%v %v has id %v and admin consent %v, expected %v and %v", k.typ, k.permission, got.ID, got.AdminConsentRequired, k.id, k.adminConsentRequired)
+		}
+		if got.DisplayString == "" || got.Description == "" {
+			t.Errorf("%v %v has no display string or description", k.typ, k.permission)
+		}
+	}
+	if n := len(All(PermissionTypeApplication)); n != applicationPermissions {
+		t.Errorf("catalog has %v application permissions, expected %v", n, applicationPermissions)
+	}
+	if n := len(All(PermissionTypeDelegated)); n != delegatedPermissions {
+		t.Errorf("catalog has %v delegated permissions, expected %v", n, delegatedPermissions)
+	}
+	if DelegatedUserRead.ID != "e1fe6dd8-ba31-4d61-89e7-88639da4683d" || ApplicationUserReadAll.ID != "df021288-bdef-4463-88db-98f22de89214" {
+		t.Error("expected the permission variables to hold their permissions")
+	}
+}
+
+func TestCatalogHasNoDuplicates(t *testing.T) {
+	permissions := map[string]bool{}
+	ids := map[string]bool{}
+	for _, s := range All(PermissionTypeAll) {
+		key := string(s.Type) + " " + strings.ToLower(s.Permission)
+		if permissions[key] {
+			t.Errorf("%v is listed twice", key)
+		}
+		permissions[key] = true
+		if ids[string(s.Type)+s.ID] {
+			t.Errorf("id %v of %v is listed twice", s.ID, key)
+		}
+		ids[string(s.Type)+s.ID] = true
+	}
+	if len(All(PermissionTypeApplication))+len(All(PermissionTypeDelegated)) != len(All(PermissionTypeAll)) {
+		t.Error("expected every permission to be either application or delegated")
+	}
+	if got := Resolve("User.Read mail.send", PermissionTypeDelegated); len(got) != 2 || got[1] != DelegatedMailSend {
+		t.Errorf("unexpected scopes %v", got)
+	}
+}
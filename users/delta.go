@@ -0,0 +1,70 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+	"github.com/cention-mujibur-rahman/msgoraph/odata"
+)
+
+// UserChange is a single entry yielded by a users delta query. Removed users only carry their ID
+// and the Removed annotation.
+type UserChange struct {
+	User
+	Removed *common.Removed `json:"@removed"`
+}
+
+// UserDeltaIterator walks the changes of a users delta query. Once it's exhausted, DeltaLink holds
+// the link to pass to the next query, and it has been saved to the store the query was started
+// with.
+type UserDeltaIterator struct {
+	it     *internal.DeltaIterator
+	change UserChange
+}
+
+// Next advances to the next change. It returns false when there are no more changes or an error
+// occurred; check Err to tell apart.
+func (i *UserDeltaIterator) Next() bool {
+	i.change = UserChange{}
+	return i.it.Next(&i.change)
+}
+
+// Change returns the change Next advanced to.
+func (i *UserDeltaIterator) Change() UserChange {
+	return i.change
+}
+
+// ChangeType tells whether the current change is an added, changed or removed user.
+func (i *UserDeltaIterator) ChangeType() common.ChangeType {
+	return i.it.ChangeType(i.change.Removed)
+}
+
+// DeltaLink returns the @odata.deltaLink to resume from next time, once the iterator is exhausted.
+func (i *UserDeltaIterator) DeltaLink() string {
+	return i.it.DeltaLink()
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (i *UserDeltaIterator) Err() error {
+	return i.it.Err()
+}
+
+// DeltaUsers runs a delta query over the users on a tenant's azure instance. If store holds a delta
+// link under key, only the users which changed since it was saved are returned; otherwise every
+// user is, projected with the given fields. The new delta link is saved under key once the iterator
+// is exhausted. The store may be nil, in which case nothing is loaded or saved. If the saved delta
+// link has expired, the iterator stops with common.ErrDeltaLinkExpired and the link is removed, so
+// querying again starts a full sync.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-delta?view=graph-rest-1.0
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersDeltaUsers).
+func (s *ServiceContext) DeltaUsers(ctx context.Context, store common.DeltaTokenStore, key string, projection []Field) *UserDeltaIterator {
+	if len(projection) == 0 {
+		return &UserDeltaIterator{it: &internal.DeltaIterator{PageIterator: internal.FailedPageIterator(fmt.Errorf("no fields provided in call to Users"))}}
+	}
+	q := odata.New().Select(Properties(projection)...)
+	return &UserDeltaIterator{it: internal.NewDeltaIterator(ctx, s.client, "v1.0/users/delta", q.Values(), store, key)}
+}
@@ -1,5 +1,9 @@
 package users
 
+import (
+	"github.com/cention-mujibur-rahman/msgoraph/odata"
+)
+
 // Field can be provided to the user request functions to select which Fields
 // are provided by Microsoft for each user. There's one for every root Field on the user object
 // and they match up perfectly with the json names above. All of these have little comments
@@ -161,3 +165,19 @@ var (
 		FieldUserPrincipalName,
 	}
 )
+
+// PropertyName conforms to the odata.Property interface, so fields can be used directly in
+// odata.Query.Select, Filter and OrderBy.
+func (f Field) PropertyName() string {
+	return string(f)
+}
+
+// Properties converts a projection, such as UserDefaultFields, into properties for
+// odata.Query.Select.
+func Properties(projection []Field) []odata.Property {
+	props := make([]odata.Property, len(projection))
+	for i, f := range projection {
+		props[i] = f
+	}
+	return props
+}
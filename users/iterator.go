@@ -0,0 +1,12 @@
+package users
+
+import (
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+// UserIterator lazily walks a list of users. See common.Iterator.
+type UserIterator = common.Iterator[User]
+
+// DirectoryObjectIterator lazily walks a list of directory objects, such as the groups and roles a
+// user is a member of. See common.Iterator.
+type DirectoryObjectIterator = common.Iterator[common.DirectoryObject]
@@ -0,0 +1,230 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+var (
+	// ErrNoManager is returned by GetManager when the user has no manager assigned, such as the
+	// head of the organization.
+	ErrNoManager = errors.New("users: user has no manager")
+
+	// ErrUserNotFound is returned by GetManager when the user doesn't exist, which the Graph API
+	// answers the same way as a user without a manager.
+	ErrUserNotFound = errors.New("users: user not found")
+)
+
+// CycleError is returned when walking the reporting lines leads back to a user already visited,
+// which the directory doesn't prevent.
+type CycleError struct {
+	// Path holds the ids of the users walked, ending with the one seen twice.
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("users: reporting cycle %v", strings.Join(e.Path, " -> "))
+}
+
+// GetManager returns the manager of a user, by id or principal name, ErrNoManager if the user has
+// none, or ErrUserNotFound if there is no such user. Telling the two apart takes a second request.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-list-manager?view=graph-rest-1.0
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersGetManager).
+func (s *ServiceContext) GetManager(userIDOrPrincipal string) (User, error) {
+	return s.GetManagerWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// GetManagerWithContext is the same as GetManager, with the request bound to ctx.
+func (s *ServiceContext) GetManagerWithContext(ctx context.Context, userIDOrPrincipal string) (User, error) {
+	reqURL := fmt.Sprintf("v1.0/users/%v/manager", userIDOrPrincipal)
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "GET", reqURL, nil, nil)
+	if client.IsNotFound(err) {
+		return User{}, s.checkUserExists(ctx, userIDOrPrincipal)
+	}
+	if err != nil {
+		return User{}, err
+	}
+	var data GetUserResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return User{}, err
+	}
+	return data.User, nil
+}
+
+// checkUserExists resolves a user whose manager wasn't found, and returns ErrNoManager if they
+// exist, or ErrUserNotFound if they don't.
+func (s *ServiceContext) checkUserExists(ctx context.Context, userIDOrPrincipal string) error {
+	reqURL := fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal)
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "GET", reqURL, url.Values{"$select": {"id"}}, nil)
+	if client.IsNotFound(err) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return ErrNoManager
+}
+
+// SetManager assigns the user with id managerID as the manager of a user, by id or principal name.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-post-manager?view=graph-rest-1.0
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersSetManager).
+func (s *ServiceContext) SetManager(userIDOrPrincipal string, managerID string) error {
+	return s.SetManagerWithContext(context.Background(), userIDOrPrincipal, managerID)
+}
+
+// SetManagerWithContext is the same as SetManager, with the request bound to ctx.
+func (s *ServiceContext) SetManagerWithContext(ctx context.Context, userIDOrPrincipal string, managerID string) error {
+	reqURL := fmt.Sprintf("v1.0/users/%v/manager/$ref", userIDOrPrincipal)
+	ref := map[string]string{
+		"@odata.id": internal.GraphURL(s.client, fmt.Sprintf("v1.0/users/%v", managerID), nil),
+	}
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "PUT", reqURL, nil, ref)
+	return err
+}
+
+// RemoveManager unassigns the manager of a user, by id or principal name.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-delete-manager?view=graph-rest-1.0
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersRemoveManager).
+func (s *ServiceContext) RemoveManager(userIDOrPrincipal string) error {
+	return s.RemoveManagerWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// RemoveManagerWithContext is the same as RemoveManager, with the request bound to ctx.
+func (s *ServiceContext) RemoveManagerWithContext(ctx context.Context, userIDOrPrincipal string) error {
+	reqURL := fmt.Sprintf("v1.0/users/%v/manager/$ref", userIDOrPrincipal)
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "DELETE", reqURL, nil, nil)
+	return err
+}
+
+// ListDirectReports returns the users who report to a user, by id or principal name. Direct
+// reports which aren't users, such as organizational contacts, are left out.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersListDirectReports).
+func (s *ServiceContext) ListDirectReports(userIDOrPrincipal string) ([]User, error) {
+	return s.ListDirectReportsWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// ListDirectReportsWithContext is the same as ListDirectReports, with the requests bound to ctx.
+func (s *ServiceContext) ListDirectReportsWithContext(ctx context.Context, userIDOrPrincipal string) ([]User, error) {
+	return s.IterateDirectReports(ctx, userIDOrPrincipal).All()
+}
+
+// IterateDirectReports returns an iterator over the users who report to a user, by id or principal
+// name. Pages are only requested as the iterator advances.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-list-directreports?view=graph-rest-1.0
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersIterateDirectReports).
+func (s *ServiceContext) IterateDirectReports(ctx context.Context, userIDOrPrincipal string) *UserIterator {
+	reqURL := fmt.Sprintf("v1.0/users/%v/directReports/microsoft.graph.user", userIDOrPrincipal)
+	return common.NewIterator[User](internal.NewPageIterator(ctx, s.client, reqURL, nil))
+}
+
+// ManagementChain returns the managers above a user, by id or principal name, starting with their
+// direct manager and ending with a user who has no manager, or after maxDepth managers. It fails
+// with a *CycleError if the chain leads back to a user already in it.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersManagementChain).
+func (s *ServiceContext) ManagementChain(ctx context.Context, userIDOrPrincipal string, maxDepth int) ([]User, error) {
+	user, err := s.GetUserWithContext(ctx, userIDOrPrincipal)
+	if err != nil {
+		return nil, err
+	}
+	path := []string{idOf(user)}
+	var chain []User
+	for len(chain) < maxDepth {
+		manager, err := s.GetManagerWithContext(ctx, path[len(path)-1])
+		if err == ErrNoManager {
+			break
+		}
+		if err != nil {
+			return chain, err
+		}
+		path = append(path, idOf(manager))
+		if contains(path[:len(path)-1], idOf(manager)) {
+			return chain, &CycleError{Path: path}
+		}
+		chain = append(chain, manager)
+	}
+	return chain, nil
+}
+
+// OrgChartNode is a user in an org chart, along with the users reporting to them.
+type OrgChartNode struct {
+	User    User
+	Reports []*OrgChartNode
+	// Truncated is set when the depth limit was reached before the reports of the user were
+	// looked up.
+	Truncated bool
+}
+
+// OrgChart walks the reporting tree below a user, by id or principal name, down to maxDepth
+// levels of reports; a maxDepth of 0 only returns the user. It fails with a *CycleError if a user
+// turns out to report to themselves, directly or not.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersOrgChart).
+func (s *ServiceContext) OrgChart(ctx context.Context, userIDOrPrincipal string, maxDepth int) (*OrgChartNode, error) {
+	user, err := s.GetUserWithContext(ctx, userIDOrPrincipal)
+	if err != nil {
+		return nil, err
+	}
+	root := &OrgChartNode{User: user}
+	if err := s.walkReports(ctx, root, []string{idOf(user)}, maxDepth); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// walkReports fills in the reports below node, whose reporting line up to the root is path.
+func (s *ServiceContext) walkReports(ctx context.Context, node *OrgChartNode, path []string, depth int) error {
+	if depth <= 0 {
+		node.Truncated = true
+		return nil
+	}
+	reports, err := s.ListDirectReportsWithContext(ctx, path[len(path)-1])
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		id := idOf(report)
+		if contains(path, id) {
+			return &CycleError{Path: append(append([]string{}, path...), id)}
+		}
+		child := &OrgChartNode{User: report}
+		if err := s.walkReports(ctx, child, append(path, id), depth-1); err != nil {
+			return err
+		}
+		node.Reports = append(node.Reports, child)
+	}
+	return nil
+}
+
+func idOf(u User) string {
+	if u.ID == nil {
+		return ""
+	}
+	return *u.ID
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+)
+
+type staticClient struct {
+	creds *client.RequestCredentials
+	opts  *client.Options
+}
+
+func (c *staticClient) Credentials() *client.RequestCredentials { return c.creds }
+func (c *staticClient) InitializeCredentials() error            { return nil }
+func (c *staticClient) RefreshCredentials() error               { return nil }
+func (c *staticClient) RequestOptions() *client.Options         { return c.opts }
+
+// orgServer serves a directory where managers maps a user id to their manager's. Users which
+// appear in neither don't exist.
+func orgServer(managers map[string]string) *httptest.Server {
+	exists := map[string]bool{}
+	for user, manager := range managers {
+		exists[user], exists[manager] = true, true
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1.0/users/"), "/")
+		id := parts[0]
+		switch {
+		case !exists[id]:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"Request_ResourceNotFound"}}`))
+		case len(parts) == 1:
+			fmt.Fprintf(w, `{"id":%q}`, id)
+		case parts[1] == "manager":
+			if managers[id] == "" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":"Request_ResourceNotFound"}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"id":%q}`, managers[id])
+		case parts[1] == "directReports":
+			var reports []string
+			for report, manager := range managers {
+				if manager == id {
+					reports = append(reports, fmt.Sprintf(`{"id":%q}`, report))
+				}
+			}
+			fmt.Fprintf(w, `{"value":[%v]}`, strings.Join(reports, ","))
+		}
+	}))
+}
+
+func testService(srv *httptest.Server) *ServiceContext {
+	return Service(&staticClient{
+		creds: &client.RequestCredentials{AccessToken: "token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+		opts:  &client.Options{GraphURL: srv.URL},
+	})
+}
+
+func TestOrgChart(t *testing.T) {
+	srv := orgServer(map[string]string{"cto": "ceo", "dev": "cto", "ops": "cto", "intern": "dev"})
+	defer srv.Close()
+	s := testService(srv)
+	ctx := context.Background()
+
+	if _, err := s.GetManager("ceo"); err != ErrNoManager {
+		t.Fatalf("expected ErrNoManager, got %v", err)
+	}
+	if _, err := s.GetManager("ghost"); err != ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+	chain, err := s.ManagementChain(ctx, "intern", 10)
+	if err != nil || len(chain) != 3 || *chain[2].ID != "ceo" {
+		t.Fatalf("unexpected chain %v, %v", chain, err)
+	}
+	root, err := s.OrgChart(ctx, "ceo", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Reports) != 1 || len(root.Reports[0].Reports) != 2 {
+		t.Fatalf("unexpected org chart %+v", root)
+	}
+	for _, node := range root.Reports[0].Reports {
+		if !node.Truncated || node.Reports != nil {
+			t.Fatalf("expected the walk to stop at the depth limit, got %+v", node)
+		}
+	}
+}
+
+func TestOrgChartCycle(t *testing.T) {
+	srv := orgServer(map[string]string{"a": "b", "b": "c", "c": "a"})
+	defer srv.Close()
+	s := testService(srv)
+	if _, err := s.OrgChart(context.Background(), "a", 10); err == nil {
+		t.Fatal("expected the cycle to be reported")
+	} else if cerr, ok := err.(*CycleError); !ok || len(cerr.Path) != 4 || cerr.Path[0] != cerr.Path[3] {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := s.ManagementChain(context.Background(), "a", 10); err == nil {
+		t.Fatal("expected the cycle to be reported")
+	}
+}
@@ -0,0 +1,126 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+// checkMemberGroupsLimit is the most group ids checkMemberGroups takes in a single request.
+const checkMemberGroupsLimit = 20
+
+// ListMemberOf returns the groups, directory roles and administrative units a user, by id or
+// principal name, is a direct member of. Tell them apart with the Type of each object. Only the
+// objects the caller may read are returned; directory roles and administrative units need
+// Directory.Read.All.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-list-memberof?view=graph-rest-1.0
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersListMemberOf).
+func (s *ServiceContext) ListMemberOf(userIDOrPrincipal string) ([]common.DirectoryObject, error) {
+	return s.ListMemberOfWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// ListMemberOfWithContext is the same as ListMemberOf, with the requests bound to ctx.
+func (s *ServiceContext) ListMemberOfWithContext(ctx context.Context, userIDOrPrincipal string) ([]common.DirectoryObject, error) {
+	return s.IterateMemberOf(ctx, userIDOrPrincipal).All()
+}
+
+// IterateMemberOf returns an iterator over the objects a user is a direct member of, as listed by
+// ListMemberOf. Pages are only requested as the iterator advances.
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersIterateMemberOf).
+func (s *ServiceContext) IterateMemberOf(ctx context.Context, userIDOrPrincipal string) *DirectoryObjectIterator {
+	reqURL := fmt.Sprintf("v1.0/users/%v/memberOf", userIDOrPrincipal)
+	return common.NewIterator[common.DirectoryObject](internal.NewPageIterator(ctx, s.client, reqURL, nil))
+}
+
+// ListTransitiveMemberOf returns the groups, directory roles and administrative units a user, by
+// id or principal name, is a member of, either directly or through nested groups.
+//
+// https://docs.microsoft.com/en-us/graph/api/user-list-transitivememberof?view=graph-rest-1.0
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersListTransitiveMemberOf).
+func (s *ServiceContext) ListTransitiveMemberOf(userIDOrPrincipal string) ([]common.DirectoryObject, error) {
+	return s.ListTransitiveMemberOfWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// ListTransitiveMemberOfWithContext is the same as ListTransitiveMemberOf, with the requests bound
+// to ctx.
+func (s *ServiceContext) ListTransitiveMemberOfWithContext(ctx context.Context, userIDOrPrincipal string) ([]common.DirectoryObject, error) {
+	return s.IterateTransitiveMemberOf(ctx, userIDOrPrincipal).All()
+}
+
+// IterateTransitiveMemberOf returns an iterator over the objects a user is a member of, as listed
+// by ListTransitiveMemberOf. Pages are only requested as the iterator advances.
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersIterateTransitiveMemberOf).
+func (s *ServiceContext) IterateTransitiveMemberOf(ctx context.Context, userIDOrPrincipal string) *DirectoryObjectIterator {
+	reqURL := fmt.Sprintf("v1.0/users/%v/transitiveMemberOf", userIDOrPrincipal)
+	return common.NewIterator[common.DirectoryObject](internal.NewPageIterator(ctx, s.client, reqURL, nil))
+}
+
+// CheckMemberGroups returns which of the given groups a user, by id or principal name, is a member
+// of, either directly or through nested groups. Any number of group ids can be checked; they are
+// sent 20 at a time.
+//
+// https://docs.microsoft.com/en-us/graph/api/directoryobject-checkmembergroups?view=graph-rest-1.0
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersCheckMemberGroups).
+func (s *ServiceContext) CheckMemberGroups(userIDOrPrincipal string, groupIDs []string) ([]string, error) {
+	return s.CheckMemberGroupsWithContext(context.Background(), userIDOrPrincipal, groupIDs)
+}
+
+// CheckMemberGroupsWithContext is the same as CheckMemberGroups, with the requests bound to ctx.
+func (s *ServiceContext) CheckMemberGroupsWithContext(ctx context.Context, userIDOrPrincipal string, groupIDs []string) ([]string, error) {
+	reqURL := fmt.Sprintf("v1.0/users/%v/checkMemberGroups", userIDOrPrincipal)
+	var members []string
+	for start := 0; start < len(groupIDs); start += checkMemberGroupsLimit {
+		end := start + checkMemberGroupsLimit
+		if end > len(groupIDs) {
+			end = len(groupIDs)
+		}
+		ids, err := s.postForIDs(ctx, reqURL, map[string]interface{}{"groupIds": groupIDs[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, ids...)
+	}
+	return members, nil
+}
+
+// GetMemberObjects returns the ids of every group, directory role and administrative unit a user,
+// by id or principal name, is a member of, either directly or through nested groups. With
+// securityEnabledOnly, only security groups are returned.
+//
+// https://docs.microsoft.com/en-us/graph/api/directoryobject-getmemberobjects?view=graph-rest-1.0
+//
+// Permissions: delegated GroupMember.Read.All, application GroupMember.Read.All (scopes.UsersGetMemberObjects).
+func (s *ServiceContext) GetMemberObjects(userIDOrPrincipal string, securityEnabledOnly bool) ([]string, error) {
+	return s.GetMemberObjectsWithContext(context.Background(), userIDOrPrincipal, securityEnabledOnly)
+}
+
+// GetMemberObjectsWithContext is the same as GetMemberObjects, with the request bound to ctx.
+func (s *ServiceContext) GetMemberObjectsWithContext(ctx context.Context, userIDOrPrincipal string, securityEnabledOnly bool) ([]string, error) {
+	reqURL := fmt.Sprintf("v1.0/users/%v/getMemberObjects", userIDOrPrincipal)
+	return s.postForIDs(ctx, reqURL, map[string]interface{}{"securityEnabledOnly": securityEnabledOnly})
+}
+
+// postForIDs posts body to a directory object action which answers with a list of ids. These
+// actions only read the directory, so a throttled request is retried even though it's a POST.
+func (s *ServiceContext) postForIDs(ctx context.Context, reqURL string, body interface{}) ([]string, error) {
+	b, err := internal.GraphRequestReplayable(ctx, s.client, "POST", reqURL, body)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Value []string `json:"value"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data.Value, nil
+}
@@ -0,0 +1,88 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+)
+
+func TestMembership(t *testing.T) {
+	var checked [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/users/u/transitiveMemberOf":
+			w.Write([]byte(`{"value":[
+				{"@odata.type":"#microsoft.graph.group","id":"g","displayName":"Engineering","securityEnabled":true},
+				{"@odata.type":"#microsoft.graph.directoryRole","id":"r","displayName":"Global Reader","roleTemplateId":"t"},
+				{"@odata.type":"#microsoft.graph.administrativeUnit","id":"a","displayName":"Europe"}
+			]}`))
+		case "/v1.0/users/u/checkMemberGroups":
+			var body struct {
+				GroupIDs []string `json:"groupIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			checked = append(checked, body.GroupIDs)
+			json.NewEncoder(w).Encode(map[string][]string{"value": body.GroupIDs[:1]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	s := testService(srv)
+
+	objects, err := s.ListTransitiveMemberOf("u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 3 || !objects[0].IsGroup() || !objects[1].IsDirectoryRole() || !objects[2].IsAdministrativeUnit() {
+		t.Fatalf("unexpected objects %+v", objects)
+	}
+	var role struct {
+		RoleTemplateID string `json:"roleTemplateId"`
+	}
+	if err := objects[1].Decode(&role); err != nil || role.RoleTemplateID != "t" {
+		t.Fatalf("unexpected role %+v, %v", role, err)
+	}
+	if objects[0].Type != common.TypeGroup || *objects[0].DisplayName != "Engineering" {
+		t.Fatalf("unexpected group %+v", objects[0])
+	}
+
+	ids := make([]string, 25)
+	for i := range ids {
+		ids[i] = string(rune('a' + i))
+	}
+	members, err := s.CheckMemberGroups("u", ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checked) != 2 || len(checked[0]) != 20 || len(checked[1]) != 5 || len(members) != 2 || members[1] != "u" {
+		t.Fatalf("unexpected batches %v, members %v", checked, members)
+	}
+}
+
+func TestGetMemberObjectsRetriesThrottled(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"value":["g"]}`))
+	}))
+	defer srv.Close()
+	s := Service(&staticClient{
+		creds: &client.RequestCredentials{AccessToken: "token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+		opts:  &client.Options{GraphURL: srv.URL, Retry: &client.RetryPolicy{MaxAttempts: 2}},
+	})
+	ids, err := s.GetMemberObjects("u", true)
+	if err != nil || calls != 2 || len(ids) != 1 {
+		t.Fatalf("expected the throttled action to be sent again, got %v, %v after %v calls", ids, err, calls)
+	}
+}
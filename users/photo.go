@@ -0,0 +1,77 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cention-mujibur-rahman/msgoraph/common"
+	"github.com/cention-mujibur-rahman/msgoraph/internal"
+)
+
+// ListPhotoSizes returns the sizes the profile photo of a user, by id or principal name, is
+// available in.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-get?view=graph-rest-1.0
+//
+// Permissions: delegated User.ReadBasic.All, application User.Read.All (scopes.UsersListPhotoSizes).
+func (s *ServiceContext) ListPhotoSizes(userIDOrPrincipal string) ([]common.ProfilePhoto, error) {
+	return s.ListPhotoSizesWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// ListPhotoSizesWithContext is the same as ListPhotoSizes, with the request bound to ctx.
+func (s *ServiceContext) ListPhotoSizesWithContext(ctx context.Context, userIDOrPrincipal string) ([]common.ProfilePhoto, error) {
+	return internal.ListPhotos(ctx, s.client, fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal))
+}
+
+// GetPhoto returns the metadata of the profile photo of a user, by id or principal name, in the
+// given size, such as "48x48", or of the largest one available when size is empty.
+//
+// Permissions: delegated User.ReadBasic.All, application User.Read.All (scopes.UsersGetPhoto).
+func (s *ServiceContext) GetPhoto(userIDOrPrincipal string, size string) (common.ProfilePhoto, error) {
+	return s.GetPhotoWithContext(context.Background(), userIDOrPrincipal, size)
+}
+
+// GetPhotoWithContext is the same as GetPhoto, with the request bound to ctx.
+func (s *ServiceContext) GetPhotoWithContext(ctx context.Context, userIDOrPrincipal string, size string) (common.ProfilePhoto, error) {
+	return internal.GetPhoto(ctx, s.client, fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal), size)
+}
+
+// DownloadPhoto streams the profile photo of a user, by id or principal name, in the given size, or
+// the largest one available when size is empty. The caller must close the Body of the photo. If
+// cache holds metadata for the photo, it's only downloaded when its ETag changed, and
+// common.ErrPhotoNotModified is returned otherwise. The cache may be nil.
+//
+// Permissions: delegated User.ReadBasic.All, application User.Read.All (scopes.UsersDownloadPhoto).
+func (s *ServiceContext) DownloadPhoto(ctx context.Context, userIDOrPrincipal string, size string, cache common.PhotoCache) (*common.Photo, error) {
+	return internal.DownloadPhoto(ctx, s.client, fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal), size, cache)
+}
+
+// UploadPhoto replaces the profile photo of a user, by id or principal name. The content type,
+// such as "image/jpeg", is sniffed from data when empty. Photos larger than common.MaxPhotoSize
+// are rejected before being sent.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-update?view=graph-rest-1.0
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersUploadPhoto).
+func (s *ServiceContext) UploadPhoto(userIDOrPrincipal string, contentType string, data []byte) error {
+	return s.UploadPhotoWithContext(context.Background(), userIDOrPrincipal, contentType, data)
+}
+
+// UploadPhotoWithContext is the same as UploadPhoto, with the request bound to ctx.
+func (s *ServiceContext) UploadPhotoWithContext(ctx context.Context, userIDOrPrincipal string, contentType string, data []byte) error {
+	return internal.UploadPhoto(ctx, s.client, fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal), contentType, data)
+}
+
+// DeletePhoto removes the profile photo of a user, by id or principal name.
+//
+// https://docs.microsoft.com/en-us/graph/api/profilephoto-delete?view=graph-rest-1.0
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersDeletePhoto).
+func (s *ServiceContext) DeletePhoto(userIDOrPrincipal string) error {
+	return s.DeletePhotoWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// DeletePhotoWithContext is the same as DeletePhoto, with the request bound to ctx.
+func (s *ServiceContext) DeletePhotoWithContext(ctx context.Context, userIDOrPrincipal string) error {
+	return internal.DeletePhoto(ctx, s.client, fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal))
+}
@@ -1,12 +1,16 @@
 package users
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/cention-mujibur-rahman/msgoraph/client"
+	"github.com/cention-mujibur-rahman/msgoraph/common"
 	"github.com/cention-mujibur-rahman/msgoraph/internal"
+	"github.com/cention-mujibur-rahman/msgoraph/odata"
 )
 
 // CreateUserRequest is all the available args you can set when creating a user.
@@ -26,6 +30,8 @@ type GetUserResponse struct {
 }
 
 // ListUsersResponse is the Response from the list users graph api endpoint
+//
+// Deprecated: the list methods follow @odata.nextLink themselves; use IterateUsers to walk the pages.
 type ListUsersResponse struct {
 	Context  string `json:"@odata.context"`
 	NextPage string `json:"@odata.nextLink"`
@@ -39,49 +45,72 @@ type ServiceContext struct {
 }
 
 // Service creates a new users.ServiceContext with the given authentication credentials.
+// Requests are sent according to the client's Options, when it has any; see client.WithOptions
+// for attaching Options to a custom client.
 func Service(client client.Client) *ServiceContext {
 	return &ServiceContext{client: client}
 }
 
-// UpdateUserRequest contains the request body to update a user.
+// UpdateUserRequest contains the request body to update a user. Only the fields which are set are
+// sent, so that the rest of the user is left as is; use the pointer helpers of the msgoraph
+// package to set them, such as msgoraph.String("Engineer"). A property is cleared by listing it in
+// NullFields.
 type UpdateUserRequest struct {
-	AboutMe               string            `json:"aboutMe"`
-	AccountEnabled        string            `json:"accountEnabled"`
+	AboutMe               *string           `json:"aboutMe"`
+	AccountEnabled        *bool             `json:"accountEnabled"`
 	AssignedLicenses      []AssignedLicense `json:"assignedLicenses"`
-	Birthday              string            `json:"birthday"`
-	City                  string            `json:"city"`
-	Country               string            `json:"country"`
-	Department            string            `json:"department"`
-	DisplayName           string            `json:"displayName"`
-	GivenName             string            `json:"givenName"`
-	HireDate              string            `json:"hireDate"`
+	Birthday              *time.Time        `json:"birthday"`
+	City                  *string           `json:"city"`
+	Country               *string           `json:"country"`
+	Department            *string           `json:"department"`
+	DisplayName           *string           `json:"displayName"`
+	GivenName             *string           `json:"givenName"`
+	HireDate              *time.Time        `json:"hireDate"`
 	Interests             []string          `json:"interests"`
-	JobTitle              string            `json:"jobTitle"`
-	MailNickname          string            `json:"mailNickname"`
-	MobilePhone           string            `json:"mobilePhone"`
-	MySite                string            `json:"mySite"`
-	OfficeLocation        string            `json:"officeLocation"`
-	OnPremisesImmutableID string            `json:"onPremisesImmutableId"`
-	PasswordPolicies      string            `json:"passwordPolicies"`
-	PasswordProfile       PasswordProfile   `json:"passwordProfile"`
+	JobTitle              *string           `json:"jobTitle"`
+	MailNickname          *string           `json:"mailNickname"`
+	MobilePhone           *string           `json:"mobilePhone"`
+	MySite                *string           `json:"mySite"`
+	OfficeLocation        *string           `json:"officeLocation"`
+	OnPremisesImmutableID *string           `json:"onPremisesImmutableId"`
+	PasswordPolicies      *string           `json:"passwordPolicies"`
+	PasswordProfile       *PasswordProfile  `json:"passwordProfile"`
 	PastProjects          []string          `json:"pastProjects"`
-	PostalCode            string            `json:"postalCode"`
-	PreferredLanguage     string            `json:"preferredLanguage"`
-	PreferredName         string            `json:"preferredName"`
+	PostalCode            *string           `json:"postalCode"`
+	PreferredLanguage     *string           `json:"preferredLanguage"`
+	PreferredName         *string           `json:"preferredName"`
 	Responsibilities      []string          `json:"responsibilities"`
 	Schools               []string          `json:"schools"`
 	Skills                []string          `json:"skills"`
-	State                 string            `json:"state"`
-	StreetAddress         string            `json:"streetAddress"`
-	Surname               string            `json:"surname"`
-	UsageLocation         string            `json:"usageLocation"`
-	UserPrincipalName     string            `json:"userPrincipalName"`
-	UserType              string            `json:"userType"`
+	State                 *string           `json:"state"`
+	StreetAddress         *string           `json:"streetAddress"`
+	Surname               *string           `json:"surname"`
+	UsageLocation         *string           `json:"usageLocation"`
+	UserPrincipalName     *string           `json:"userPrincipalName"`
+	UserType              *string           `json:"userType"`
+
+	// NullFields lists the properties to clear, such as FieldMobilePhone.
+	NullFields []Field `json:"-"`
+}
+
+// MarshalJSON only marshals the fields which are set, along with NullFields as null.
+func (u UpdateUserRequest) MarshalJSON() ([]byte, error) {
+	return internal.MarshalPatch(u)
 }
 
 // CreateUser creates a new user in the tenant.
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersCreateUser).
 func (s *ServiceContext) CreateUser(createUser CreateUserRequest) (User, error) {
-	body, err := internal.GraphRequest(s.client, "POST", "v1.0/users", nil, createUser)
+	return s.CreateUserWithContext(context.Background(), createUser)
+}
+
+// CreateUserWithContext is the same as CreateUser, with the request bound to ctx.
+func (s *ServiceContext) CreateUserWithContext(ctx context.Context, createUser CreateUserRequest) (User, error) {
+	body, err := internal.GraphRequestWithContext(ctx, s.client, "POST", "v1.0/users", nil, createUser)
+	if err != nil {
+		return User{}, err
+	}
 	var data GetUserResponse
 	err = json.Unmarshal(body, &data)
 	if err != nil {
@@ -91,36 +120,59 @@ func (s *ServiceContext) CreateUser(createUser CreateUserRequest) (User, error)
 }
 
 // DeleteUser deletes an existing user by id or principal name.
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersDeleteUser).
 func (s *ServiceContext) DeleteUser(userIDOrPrincipal string) error {
+	return s.DeleteUserWithContext(context.Background(), userIDOrPrincipal)
+}
+
+// DeleteUserWithContext is the same as DeleteUser, with the request bound to ctx.
+func (s *ServiceContext) DeleteUserWithContext(ctx context.Context, userIDOrPrincipal string) error {
 	reqURL := fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal)
-	_, err := internal.GraphRequest(s.client, "DELETE", reqURL, nil, nil)
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "DELETE", reqURL, nil, nil)
 	return err
 }
 
 // GetUser returns a single user by id or principal name, with the Microsoft default fields
 // provided, identical to those specified in UserDefaultFields.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersGetUser).
 func (s *ServiceContext) GetUser(userIDOrPrincipal string) (User, error) {
 	return s.GetUserWithFields(userIDOrPrincipal, UserDefaultFields)
 }
 
+// GetUserWithContext is the same as GetUser, with the request bound to ctx.
+func (s *ServiceContext) GetUserWithContext(ctx context.Context, userIDOrPrincipal string) (User, error) {
+	return s.GetUserWithFieldsWithContext(ctx, userIDOrPrincipal, UserDefaultFields)
+}
+
 // GetUserWithFields returns a single user by id or principal name. You need to specify a list of
 // fields you want to project on the user returned. You can specify UserDefaultFields or
 // UserAllFields, or customize it depending on what you want.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersGetUserWithFields).
 func (s *ServiceContext) GetUserWithFields(userIDOrPrincipal string, projection []Field) (User, error) {
+	return s.GetUserWithFieldsWithContext(context.Background(), userIDOrPrincipal, projection)
+}
+
+// GetUserWithFieldsWithContext is the same as GetUserWithFields, with the request bound to ctx.
+func (s *ServiceContext) GetUserWithFieldsWithContext(ctx context.Context, userIDOrPrincipal string, projection []Field) (User, error) {
 	if len(projection) == 0 {
 		return User{}, fmt.Errorf("no fields provided in call to Users")
 	}
-	selectFields := ""
-	for i, requestField := range projection {
-		if i != 0 {
-			selectFields += ","
-		}
-		selectFields += string(requestField)
-	}
-	v := url.Values{}
-	v.Set("$select", selectFields)
+	return s.GetUserWithQuery(ctx, userIDOrPrincipal, odata.New().Select(Properties(projection)...))
+}
+
+// GetUserWithQuery returns a single user by id or principal name, shaped by an OData query; usually
+// a $select and perhaps an $expand.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersGetUserWithQuery).
+func (s *ServiceContext) GetUserWithQuery(ctx context.Context, userIDOrPrincipal string, q *odata.Query) (User, error) {
 	reqURL := fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal)
-	b, err := internal.GraphRequest(s.client, "GET", reqURL, v, nil)
+	b, err := internal.GraphRequestWithHeader(ctx, s.client, "GET", reqURL, q.Values(), q.Header(), nil)
+	if err != nil {
+		return User{}, err
+	}
 	var data GetUserResponse
 	err = json.Unmarshal(b, &data)
 	if err != nil {
@@ -131,60 +183,95 @@ func (s *ServiceContext) GetUserWithFields(userIDOrPrincipal string, projection
 
 // ListUsers returns all users in the tenant, with each user projected with the Microsoft-defined
 // default fields identical to UserDefaultFields.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersListUsers).
 func (s *ServiceContext) ListUsers() ([]User, error) {
 	return s.ListUsersWithFields(UserDefaultFields)
 }
 
+// ListUsersWithContext is the same as ListUsers, with the requests bound to ctx.
+func (s *ServiceContext) ListUsersWithContext(ctx context.Context) ([]User, error) {
+	return s.ListUsersWithFieldsWithContext(ctx, UserDefaultFields)
+}
+
 // ListUsersWithFields returns the users on a tenant's azure instance. You need to specify a list of
 // fields you want to project on the users returned. You can specify UserDefaultFields or
 // UserAllFields, or customize it depending on what you want.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersListUsersWithFields).
 func (s *ServiceContext) ListUsersWithFields(projection []Field) ([]User, error) {
-	getUserPage := func(url string) ([]User, string, error) {
-		b, err := internal.BasicGraphRequest(s.client, "GET", url)
-		var data ListUsersResponse
-		err = json.Unmarshal(b, &data)
-		if err != nil {
-			return nil, "", err
-		}
-		return data.Value, data.NextPage, nil
-	}
-	var users []User
+	return s.ListUsersWithFieldsWithContext(context.Background(), projection)
+}
+
+// ListUsersWithFieldsWithContext is the same as ListUsersWithFields, with the requests bound to
+// ctx. Cancelling ctx stops the crawl between pages as well as the page request in flight.
+func (s *ServiceContext) ListUsersWithFieldsWithContext(ctx context.Context, projection []Field) ([]User, error) {
 	if len(projection) == 0 {
 		return nil, fmt.Errorf("no fields provided in call to Users")
 	}
-	filter := "$select="
-	for _, requestField := range projection {
-		filter += string(requestField) + ","
-	}
-	nextURL := fmt.Sprintf("https://graph.microsoft.com/v1.0/users?%v", filter)
-	for nextURL != "" {
-		pageUsers, next, err := getUserPage(nextURL)
-		if err != nil {
-			return nil, err
-		}
-		for _, pageUser := range pageUsers {
-			users = append(users, pageUser)
-		}
-		nextURL = next
+	return s.ListUsersWithQuery(ctx, odata.New().Select(Properties(projection)...))
+}
+
+// ListUsersWithQuery returns the users on a tenant's azure instance which match an OData query. The
+// ConsistencyLevel header is sent along when the query needs it.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersListUsersWithQuery).
+func (s *ServiceContext) ListUsersWithQuery(ctx context.Context, q *odata.Query) ([]User, error) {
+	return s.IterateUsersWithQuery(ctx, q).All()
+}
+
+// IterateUsers returns an iterator over the users on a tenant's azure instance, projected with the
+// given fields. Pages are only requested as the iterator advances.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersIterateUsers).
+func (s *ServiceContext) IterateUsers(ctx context.Context, projection []Field) *UserIterator {
+	if len(projection) == 0 {
+		return common.NewIterator[User](internal.FailedPageIterator(fmt.Errorf("no fields provided in call to Users")))
 	}
-	return users, nil
+	return s.IterateUsersWithQuery(ctx, odata.New().Select(Properties(projection)...))
+}
+
+// IterateUsersWithQuery returns an iterator over the users which match an OData query. Count is
+// available on the iterator when the query asked for it.
+//
+// Permissions: delegated User.Read.All, application User.Read.All (scopes.UsersIterateUsersWithQuery).
+func (s *ServiceContext) IterateUsersWithQuery(ctx context.Context, q *odata.Query) *UserIterator {
+	it := internal.NewPageIterator(ctx, s.client, "v1.0/users", q.Values()).WithHeader(q.Header())
+	return common.NewIterator[User](it)
 }
 
 // UpdateUser updates a user in the microsoft graph api, by userid or principal name, which is
-// usually their email address. You can provide as few or many fields in the request as you'd like
-// to update.
+// usually their email address. Only the fields set in the request, and its NullFields, are
+// changed.
+//
+// Permissions: delegated User.ReadWrite.All, application User.ReadWrite.All (scopes.UsersUpdateUser).
 func (s *ServiceContext) UpdateUser(userIDOrPrincipal string, u UpdateUserRequest) error {
+	return s.UpdateUserWithContext(context.Background(), userIDOrPrincipal, u)
+}
+
+// UpdateUserWithContext is the same as UpdateUser, with the request bound to ctx.
+func (s *ServiceContext) UpdateUserWithContext(ctx context.Context, userIDOrPrincipal string, u UpdateUserRequest) error {
 	reqURL := fmt.Sprintf("v1.0/users/%v", userIDOrPrincipal)
-	_, err := internal.GraphRequest(s.client, "PATCH", reqURL, nil, u)
+	_, err := internal.GraphRequestWithContext(ctx, s.client, "PATCH", reqURL, nil, u)
 	return err
 }
 
 // GetLoggedUser returns a single user by id or principal name, with the Microsoft default fields
 // provided, identical to those specified in UserDefaultFields.
+//
+// Permissions: delegated User.Read, application not supported (scopes.UsersGetLoggedUser).
 func (s *ServiceContext) GetLoggedUser() (User, error) {
+	return s.GetLoggedUserWithContext(context.Background())
+}
+
+// GetLoggedUserWithContext is the same as GetLoggedUser, with the request bound to ctx.
+func (s *ServiceContext) GetLoggedUserWithContext(ctx context.Context) (User, error) {
 	v := url.Values{}
 	//reqURL := fmt.Sprintf("v1.0/me", userIDOrPrincipal)
-	b, err := internal.GraphRequest(s.client, "GET", "v1.0/me", v, nil)
+	b, err := internal.GraphRequestWithContext(ctx, s.client, "GET", "v1.0/me", v, nil)
+	if err != nil {
+		return User{}, err
+	}
 	var data GetUserResponse
 	err = json.Unmarshal(b, &data)
 	if err != nil {